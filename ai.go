@@ -26,8 +26,6 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 package main
 
-import "fmt"
-
 const (
 	// Types of AI.
 	NoAI = iota
@@ -36,187 +34,77 @@ const (
 	MeleePatherAI
 	RangedDumbAI
 	RangedPatherAI
+	MerchantAI
+	CompanionAI
 )
 
-const (
-	// Probability of triggering AI
-	AITrigger = 92
-)
-
-func CreaturesTakeTurn(b Board, c Creatures, o Objects) {
+func CreaturesTakeTurn(b Board, c Creatures, o *Objects) {
 	/* Function CreaturesTakeTurn is supposed to handle all enemy creatures
 	   actions: movement, attacking, etc.
-	   It takes Board and Creatures as arguments.
-	   Iterates through all Creatures slice, and calls HandleAI function with
-	   specific parameters.
-	   It skips NoAI and PlayerAI. */
-	var ai int
-	for _, v := range c {
-		ai = v.AIType
-		if ai == NoAI || ai == PlayerAI {
+	   It takes Board, Creatures and Objects as arguments.
+	   It is called after player action, and it runs time until player
+	   is able to act again (or dies). It asks NextActor who should
+	   act now; if nobody has enough Energy, next Tick is performed.
+	   Fast Creatures may act several times before player's next turn,
+	   while slow ones may not act at all.
+	   Noises made since the previous action are propagated, and morale
+	   and memory of Creature are updated before its action. */
+	for c[0].HPCurrent > 0 {
+		PropagateNoises(b, c)
+		actor := NextActor(c)
+		if actor == nil {
+			Tick(b, c, o)
 			continue
 		}
-		HandleAI(b, c, o, v)
-		TriggerAI(b, c[0], v)
+		if actor == c[0] {
+			break
+		}
+		energy := actor.Energy
+		actor.UpdateMorale(b, c)
+		actor.UpdateMemory(b, c)
+		HandleAI(b, c, o, actor)
+		if actor.Energy == energy {
+			// Failed actions (like bumping into wall) take time as well.
+			actor.SpendEnergy(ActionCostWait)
+		}
+		if actor.HPCurrent > 0 {
+			TriggerAI(b, c, actor)
+		}
 	}
 }
 
-func TriggerAI(b Board, p, c *Creature) {
-	/* TriggerAI is function that takes Board and two Creatures as arguments.
-	   First Creature is supposed to be player, second one - enemy.
-	   Enemy with AITriggered set to false will ignore the player existence.
-	   AITrigger is probability to notice (and, therefore, switch AITriggered)
-	   player if is in monster's FOV. */
-	if IsInFOV(b, p.X, p.Y, c.X, c.Y) == true && RandInt(100) <= AITrigger {
-		c.AITriggered = true
+func TriggerAI(b Board, cs Creatures, c *Creature) {
+	/* TriggerAI is function that takes Board, all Creatures, and
+	   specific Creature as arguments.
+	   Creature that is not alert will ignore its enemies.
+	   Every turn, Creature may notice hostile Creature (like player)
+	   that is in its FOV; chance depends on awareness of Creature,
+	   distance, and stealth of its enemy (check stealth.go).
+	   Unaware Creature that notices something becomes suspicious,
+	   and suspicious one becomes alert, and alerts its group.
+	   Sleeping Creatures do not notice anything. */
+	if c.Awareness == AwarenessAsleep || c.Awareness == AwarenessAlert {
+		return
 	}
+	t := VisibleHostile(c, b, cs)
+	if t == nil || RandInt(100) >= c.NoticeChance(t, b) {
+		return
+	}
+	c.Notice(t, cs)
 }
 
-func HandleAI(b Board, cs Creatures, o Objects, c *Creature) {
-	/* HandleAI is robust function that takes Board, Creatures, Objects,
+func HandleAI(b Board, cs Creatures, o *Objects, c *Creature) {
+	/* HandleAI is function that takes Board, Creatures, Objects,
 	   and specific Creature as arguments. The most notable argument is
 	   the last one - behavior of this entity will be decided in function body.
-	   Its behavior will be decided regarding to AIType.
-	   This function is very big and *wet*, but it is here to stay, for a while,
-	   at least. I thought about code duplication removal by introducing one
-	   generic function that would take Creature as argument, and - after
-	   AIType check - would use proper HandleMeleeDumbAI (etc.) functions; or
-	   would start with available weapons check. (One may want to peek at
-	   issue #98 in repo - https://github.com/VedVid/RAWIG/issues/98 ).
-	   But, on the other hand, ai has so many variations and edge cases that
-	   unifying monster's behavior would result in smaller flexibility. */
-	ai := c.AIType
-	switch ai {
-	case MeleeDumbAI:
-		if c.AITriggered == true {
-			if c.DistanceTo(cs[0].X, cs[0].Y) > 1 {
-				c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-			} else {
-				c.AttackTarget(cs[0], &o)
-			}
-		} else {
-			dx := RandRange(-1, 1)
-			dy := RandRange(-1, 1)
-			c.Move(dx, dy, b)
-		}
-	case MeleePatherAI:
-		// The same set of functions as for DumbAI.
-		// Just for clarity.
-		if c.AITriggered == true {
-			if c.DistanceTo(cs[0].X, cs[0].Y) > 1 {
-				c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-			} else {
-				c.AttackTarget(cs[0], &o)
-			}
-		} else {
-			dx := RandRange(-1, 1)
-			dy := RandRange(-1, 1)
-			c.Move(dx, dy, b)
-		}
-	case RangedDumbAI:
-		if c.AITriggered == true {
-			if c.Equipment[SlotWeaponPrimary] != nil {
-				// Use primary ranged weapon.
-				if c.DistanceTo(cs[0].X, cs[0].Y) >= FOVLength-1 {
-					// TODO:
-					// For now, every ranged skill has range equal to FOVLength-1
-					// but it should change in future.
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					// DumbAI will not check if target is valid
-					vec, err := NewBrensenham(c.X, c.Y, cs[0].X, cs[0].Y)
-					if err != nil {
-						fmt.Println(err)
-					}
-					_ = ComputeBrensenham(vec)
-					_, _, target, _ := ValidateBrensenham(vec, b, cs, o)
-					if target != nil {
-						c.AttackTarget(target, &o)
-					}
-				}
-			} else if c.Equipment[SlotWeaponSecondary] != nil {
-				// Use secondary ranged weapon.
-				if c.DistanceTo(cs[0].X, cs[0].Y) >= FOVLength-1 {
-					// TODO:
-					// For now, every ranged skill has range equal to FOVLength-1
-					// but it should change in future.
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					// DumbAI will not check if target is valid
-					vec, err := NewBrensenham(c.X, c.Y, cs[0].X, cs[0].Y)
-					if err != nil {
-						fmt.Println(err)
-					}
-					_ = ComputeBrensenham(vec)
-					_, _, target, _ := ValidateBrensenham(vec, b, cs, o)
-					if target != nil {
-						c.AttackTarget(target, &o)
-					}
-				}
-			} else {
-				if c.DistanceTo(cs[0].X, cs[0].Y) > 1 {
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					c.AttackTarget(cs[0], &o)
-				}
-			}
-		} else {
-			dx := RandRange(-1, 1)
-			dy := RandRange(-1, 1)
-			c.Move(dx, dy, b)
-		}
-	case RangedPatherAI: // It will depend on ranged weapons and equipment implementation
-		if c.AITriggered == true {
-			if c.Equipment[SlotWeaponPrimary] != nil {
-				if c.DistanceTo(cs[0].X, cs[0].Y) >= FOVLength-1 {
-					// TODO:
-					// For now, every ranged skill has range equal to FOVLength-1
-					// but it should change in future.
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					vec, err := NewBrensenham(c.X, c.Y, cs[0].X, cs[0].Y)
-					if err != nil {
-						fmt.Println(err)
-					}
-					_ = ComputeBrensenham(vec)
-					_, _, target, _ := ValidateBrensenham(vec, b, cs, o)
-					if target != cs[0] {
-						c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-					} else {
-						c.AttackTarget(target, &o)
-					}
-				}
-			} else if c.Equipment[SlotWeaponSecondary] != nil {
-				if c.DistanceTo(cs[0].X, cs[0].Y) >= FOVLength-1 {
-					// TODO:
-					// For now, every ranged skill has range equal to FOVLength-1
-					// but it should change in future.
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					vec, err := NewBrensenham(c.X, c.Y, cs[0].X, cs[0].Y)
-					if err != nil {
-						fmt.Println(err)
-					}
-					_ = ComputeBrensenham(vec)
-					_, _, target, _ := ValidateBrensenham(vec, b, cs, o)
-					if target != cs[0] {
-						c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-					} else {
-						c.AttackTarget(target, &o)
-					}
-				}
-			} else {
-				if c.DistanceTo(cs[0].X, cs[0].Y) > 1 {
-					c.MoveTowards(b, cs, cs[0].X, cs[0].Y, ai)
-				} else {
-					c.AttackTarget(cs[0], &o)
-				}
-			}
-		} else {
-			dx := RandRange(-1, 1)
-			dy := RandRange(-1, 1)
-			c.Move(dx, dy, b)
-		}
-
+	   It used to be big switch over AIType, with many copies of the same
+	   code (see issue #98 in repo - https://github.com/VedVid/RAWIG/issues/98 ).
+	   Now, behavior of Creature is composed of reusable nodes, declared
+	   in json files as Behaviours - check behaviours.go for details.
+	   Creatures without Behaviours use default ones for their AIType.
+	   Sleeping Creatures do nothing. */
+	if c.Awareness == AwarenessAsleep {
+		return
 	}
+	c.RunBehaviours(b, cs, o)
 }
@@ -0,0 +1,389 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// Types of behaviour nodes.
+	BehaviourAttack       = "attack"
+	BehaviourApproach     = "approach"
+	BehaviourShoot        = "shoot"
+	BehaviourKeepDistance = "keep_distance"
+	BehaviourFlee         = "flee"
+	BehaviourKite         = "kite"
+	BehaviourEngage       = "engage"
+	BehaviourReposition   = "reposition"
+	BehaviourFollow       = "follow"
+	BehaviourPatrol       = "patrol"
+	BehaviourInvestigate  = "investigate"
+	BehaviourWander       = "wander"
+	BehaviourWait         = "wait"
+)
+
+// Behaviour is single building block of monster AI.
+type Behaviour struct {
+	/* Behaviours are declared in json files of monsters, as list,
+	   ordered by priority. Every turn, Creature tries to execute
+	   them one by one, and stops at the first one that succeeded
+	   (it works like selector node of behaviour tree).
+	   Meaning of fields depends on Type:
+	   - attack: melee attack, if target is adjacent;
+	   - approach: moves towards target, if it is farther than
+	     Distance (default 1); Pather uses pathfinding; Surround
+	     makes groups surround target (see groups.go);
+	   - shoot: uses ranged weapon, if target is within range
+	     of weapon (but not adjacent);
+	     Clear requires clear line of fire - otherwise, Creature
+	     shoots anyway, and hits whatever stands on the line;
+	   - keep_distance: steps away from target, if it is closer
+	     than Distance;
+	   - kite: steps away from adjacent target, if Creature has
+	     ranged weapon; fails if Creature is cornered;
+	   - engage: moves towards target until it is within ideal
+	     distance for ranged weapon of Creature; Pather uses
+	     pathfinding;
+	   - reposition: looks for the nearest tile with clear line
+	     of fire, if the current one is blocked;
+	   - flee: runs away from enemies, if morale of Creature
+	     broke (check morale.go);
+	   - follow: executes orders of player - follows player, stays,
+	     or lets next nodes attack ordered target (check
+	     companions.go); Distance is how close to player Creature
+	     stays (default 2); Pather uses pathfinding;
+	   - patrol: walks patrol route, guards post, or wanders in idle
+	     area (set by json map) if Creature is not fighting; Pather
+	     uses pathfinding (check patrol.go);
+	   - investigate: goes to the last known position of target
+	     (or to origin of heard noise), and searches there;
+	     Pather uses pathfinding;
+	   - wander: random step, if Creature is not triggered
+	     (or has no target);
+	   - wait: does nothing, always succeeds.
+	   Every node except wander and wait requires Creature
+	   to be triggered. */
+	Type     string
+	Distance int
+	Pather   bool
+	Clear    bool
+	Surround bool
+}
+
+// Behaviours is list of nodes, ordered by priority.
+type Behaviours []Behaviour
+
+// BehaviourHandler is function that executes single Behaviour node.
+// It returns true if node succeeded, ie Creature acted.
+type BehaviourHandler func(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool
+
+// BehaviourHandlers is registry of all behaviour node types.
+var BehaviourHandlers = map[string]BehaviourHandler{}
+
+// DefaultBehaviours are used by Creatures that do not declare
+// Behaviours in json - they mimic old, hardcoded AI types.
+var DefaultBehaviours = map[int]Behaviours{
+	MeleeDumbAI: Behaviours{
+		Behaviour{Type: BehaviourFlee},
+		Behaviour{Type: BehaviourAttack},
+		Behaviour{Type: BehaviourApproach},
+		Behaviour{Type: BehaviourInvestigate},
+		Behaviour{Type: BehaviourPatrol, Pather: true},
+		Behaviour{Type: BehaviourWander},
+	},
+	MeleePatherAI: Behaviours{
+		Behaviour{Type: BehaviourFlee},
+		Behaviour{Type: BehaviourAttack},
+		Behaviour{Type: BehaviourApproach, Pather: true, Surround: true},
+		Behaviour{Type: BehaviourInvestigate, Pather: true},
+		Behaviour{Type: BehaviourPatrol, Pather: true},
+		Behaviour{Type: BehaviourWander},
+	},
+	RangedDumbAI: Behaviours{
+		Behaviour{Type: BehaviourFlee},
+		Behaviour{Type: BehaviourShoot},
+		Behaviour{Type: BehaviourAttack},
+		Behaviour{Type: BehaviourEngage},
+		Behaviour{Type: BehaviourInvestigate},
+		Behaviour{Type: BehaviourPatrol, Pather: true},
+		Behaviour{Type: BehaviourWander},
+	},
+	RangedPatherAI: Behaviours{
+		Behaviour{Type: BehaviourFlee},
+		Behaviour{Type: BehaviourKite},
+		Behaviour{Type: BehaviourShoot, Clear: true},
+		Behaviour{Type: BehaviourReposition},
+		Behaviour{Type: BehaviourAttack},
+		Behaviour{Type: BehaviourEngage, Pather: true},
+		Behaviour{Type: BehaviourInvestigate, Pather: true},
+		Behaviour{Type: BehaviourPatrol, Pather: true},
+		Behaviour{Type: BehaviourWander},
+	},
+	MerchantAI: Behaviours{
+		Behaviour{Type: BehaviourWait},
+	},
+	CompanionAI: Behaviours{
+		Behaviour{Type: BehaviourFlee},
+		Behaviour{Type: BehaviourAttack},
+		Behaviour{Type: BehaviourShoot, Clear: true},
+		Behaviour{Type: BehaviourFollow, Pather: true},
+		Behaviour{Type: BehaviourApproach, Pather: true},
+		Behaviour{Type: BehaviourWait},
+	},
+}
+
+func InitializeBehaviours() {
+	/* Function InitializeBehaviours registers handlers of all behaviour
+	   node types. New types of nodes should be added here. It is called
+	   during initialization of the game, before loading any data
+	   from json. */
+	RegisterBehaviour(BehaviourAttack, BehaviourAttackHandler)
+	RegisterBehaviour(BehaviourApproach, BehaviourApproachHandler)
+	RegisterBehaviour(BehaviourShoot, BehaviourShootHandler)
+	RegisterBehaviour(BehaviourKeepDistance, BehaviourKeepDistanceHandler)
+	RegisterBehaviour(BehaviourFlee, BehaviourFleeHandler)
+	RegisterBehaviour(BehaviourKite, BehaviourKiteHandler)
+	RegisterBehaviour(BehaviourEngage, BehaviourEngageHandler)
+	RegisterBehaviour(BehaviourReposition, BehaviourRepositionHandler)
+	RegisterBehaviour(BehaviourFollow, BehaviourFollowHandler)
+	RegisterBehaviour(BehaviourPatrol, BehaviourPatrolHandler)
+	RegisterBehaviour(BehaviourInvestigate, BehaviourInvestigateHandler)
+	RegisterBehaviour(BehaviourWander, BehaviourWanderHandler)
+	RegisterBehaviour(BehaviourWait, BehaviourWaitHandler)
+}
+
+func RegisterBehaviour(behaviourType string, handler BehaviourHandler) {
+	/* Function RegisterBehaviour adds handler to BehaviourHandlers
+	   registry. */
+	BehaviourHandlers[behaviourType] = handler
+}
+
+func ValidateBehaviours(behaviours Behaviours) error {
+	/* Function ValidateBehaviours checks if every node in slice
+	   has registered type. It is used during creating Creatures
+	   from json files. */
+	var err error
+	for _, v := range behaviours {
+		if _, ok := BehaviourHandlers[v.Type]; ok == false {
+			txt := BehaviourError(v.Type)
+			err = errors.New("Behaviour has unknown type." + txt)
+		}
+	}
+	return err
+}
+
+func (c *Creature) ActiveBehaviours() Behaviours {
+	/* Method ActiveBehaviours returns Behaviours of receiver;
+	   if they are not declared, default ones for its AIType
+	   are used instead. */
+	if len(c.Behaviours) > 0 {
+		return c.Behaviours
+	}
+	return DefaultBehaviours[c.AIType]
+}
+
+func (c *Creature) RunBehaviours(b Board, cs Creatures, o *Objects) {
+	/* Method RunBehaviours executes Behaviours of receiver, ordered by
+	   priority, until one of them succeeds. */
+	for _, v := range c.ActiveBehaviours() {
+		handler, ok := BehaviourHandlers[v.Type]
+		if ok == false {
+			txt := BehaviourError(v.Type)
+			fmt.Println(errors.New("Behaviour has unknown type." + txt))
+			continue
+		}
+		if handler(v, c, b, cs, o) == true {
+			return
+		}
+	}
+}
+
+func AITarget(c *Creature, b Board, cs Creatures) *Creature {
+	/* Function AITarget returns Creature that is target of c:
+	   Creature that c was ordered to attack (if c is companion -
+	   see companions.go), or the closest hostile Creature it can
+	   see. Creatures do not know where their enemies are if they
+	   can not see them - they have to investigate (see noise.go).
+	   Returns nil if c has no target. */
+	if t := c.OrderedTarget(cs); t != nil {
+		return t
+	}
+	return VisibleHostile(c, b, cs)
+}
+
+func MovementStyle(n Behaviour) int {
+	/* Function MovementStyle converts Pather field of node to
+	   style of movement, used by MoveTowards. */
+	if n.Pather == true {
+		return MeleePatherAI
+	}
+	return MeleeDumbAI
+}
+
+func (c *Creature) StepAway(b Board, cs Creatures, tx, ty int) bool {
+	/* Method StepAway moves receiver to adjacent tile that is the
+	   farthest from tx, ty. It fails if no free tile increases
+	   distance to tx, ty. */
+	bestX, bestY := 0, 0
+	best := c.DistanceTo(tx, ty)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			x, y := c.X+dx, c.Y+dy
+			if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+				continue
+			}
+			if b[x][y].Blocked == true {
+				continue
+			}
+			if m := FindMonsterByXY(x, y, cs); m != nil && m.HPCurrent > 0 {
+				continue
+			}
+			dist := DistanceBetween(x, y, tx, ty)
+			if dist > best {
+				best, bestX, bestY = dist, dx, dy
+			}
+		}
+	}
+	if bestX == 0 && bestY == 0 {
+		return false
+	}
+	return c.Move(bestX, bestY, b)
+}
+
+func BehaviourAttackHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourAttackHandler attacks adjacent target. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert || c.DistanceTo(t.X, t.Y) > 1 {
+		return false
+	}
+	c.AttackTarget(t, b, o, cs)
+	return true
+}
+
+func BehaviourApproachHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourApproachHandler moves receiver towards
+	   target, until it is within Distance. Nodes with Surround
+	   set to true go to flanking tile instead (see groups.go). */
+	t := AITarget(c, b, cs)
+	distance := n.Distance
+	if distance < 1 {
+		distance = 1
+	}
+	if t == nil || c.Awareness != AwarenessAlert || c.DistanceTo(t.X, t.Y) <= distance {
+		return false
+	}
+	tx, ty := t.X, t.Y
+	if n.Surround == true {
+		tx, ty = c.FlankingTile(t, b, cs)
+	}
+	c.MoveTowards(b, cs, tx, ty, MovementStyle(n))
+	return true
+}
+
+func BehaviourShootHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourShootHandler fires ranged weapon at target,
+	   if it is in range of weapon (see ranged_ai.go).
+	   Nodes with Clear set to true fire only if target is
+	   the first Creature on the line of fire. Creatures never
+	   shoot through their allies - they step aside, if possible. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert || c.RangedWeapon() == nil ||
+		c.InRange(t) == false {
+		return false
+	}
+	vec, err := NewBrensenham(c.X, c.Y, t.X, t.Y)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_ = ComputeBrensenham(vec)
+	_, _, target, _ := ValidateBrensenham(vec, b, cs, *o)
+	if target != nil && target != t && c.Relation(target) == RelationAllied {
+		// Never shoot allies; try to find better position instead.
+		if dx, dy, ok := c.FindFiringPosition(t, b, cs, *o); ok == true {
+			return c.Move(dx, dy, b)
+		}
+		return false
+	}
+	if target == nil || (n.Clear == true && target != t) {
+		return false
+	}
+	c.AttackTarget(target, b, o, cs)
+	return true
+}
+
+func BehaviourKeepDistanceHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourKeepDistanceHandler steps away from target
+	   that is closer than Distance. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert || c.DistanceTo(t.X, t.Y) >= n.Distance {
+		return false
+	}
+	return c.StepAway(b, cs, t.X, t.Y)
+}
+
+func BehaviourFleeHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourFleeHandler runs away from visible enemies,
+	   using safety map, if receiver is fleeing (check morale.go).
+	   Fleeing Creature that does not see any enemy stays in place
+	   to recover. Cornered Creature does not flee - node fails,
+	   and Creature may fight back. */
+	if c.Fleeing == false {
+		return false
+	}
+	threats := VisibleHostiles(c, b, cs)
+	if len(threats) == 0 {
+		return true
+	}
+	return c.FleeFrom(b, cs, threats)
+}
+
+func BehaviourWanderHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourWanderHandler takes random step. Triggered
+	   Creatures wander only if they have no target. */
+	if c.Awareness == AwarenessAlert && AITarget(c, b, cs) != nil {
+		return false
+	}
+	dx := RandRange(-1, 1)
+	dy := RandRange(-1, 1)
+	c.Move(dx, dy, b)
+	return true
+}
+
+func BehaviourWaitHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourWaitHandler does nothing. */
+	return true
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+func (o *Object) HasCharges() bool {
+	/* Method HasCharges returns true if receiver may be used - either
+	   it does not use charges at all, or it has at least one left. */
+	return o.ChargesMax == 0 || o.Charges > 0
+}
+
+func (o *Object) UseCharge() bool {
+	/* Method UseCharge drains one charge of receiver. It returns true
+	   if item should be destroyed after use - that is, if it does not
+	   use charges at all, or if it is consumable and its last
+	   charge was just used. */
+	if o.ChargesMax == 0 {
+		return true
+	}
+	o.Charges--
+	return o.Charges <= 0 && o.Consumable == true
+}
+
+func (o *Object) Recharge() {
+	/* Method Recharge is called every turn. Rechargeable items that
+	   are not fully charged restore one charge every RechargeTime turns. */
+	if o.RechargeTime <= 0 || o.Charges >= o.ChargesMax {
+		return
+	}
+	o.RechargeCounter++
+	if o.RechargeCounter >= o.RechargeTime {
+		o.Charges++
+		o.RechargeCounter = 0
+	}
+}
+
+func (c *Creature) RechargeItems() {
+	/* Method RechargeItems recharges every item carried by receiver,
+	   both equipped and in Inventory. */
+	for _, v := range c.Equipment {
+		if v != nil {
+			v.Recharge()
+		}
+	}
+	for _, v := range c.Inventory {
+		if v != nil {
+			v.Recharge()
+		}
+	}
+}
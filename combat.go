@@ -26,48 +26,101 @@ OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 package main
 
-func (c *Creature) AttackTarget(t *Creature, o *Objects) {
+func (c *Creature) AttackTarget(t *Creature, b Board, o *Objects, cs Creatures) {
 	/* Method Attack handles damage rolls for combat. Receiver "c" is attacker,
 	   argument "t" is target. Including o *Objects is necessary for dropping
-	   loot by dead enemies.
+	   loot by dead enemies; Board and Creatures are passed to listeners
+	   of combat event stream.
 	   Critical hit is if attack roll is the same as receiver
 	   attack attribute.
-	   Result of attack is displayed in combat log, but messages need more polish. */
-	att := RandInt(c.Attack) //basic attack roll
-	att2 := 0                //critical bonus
-	def := t.Defense         //opponent's defense
-	dmg := 0                 //dmg delivered
-	crit := false            //was it critical hit?
-	if att == c.Attack {     //critical hit!
+	   Both attack and defense values are effective ones, ie base stats
+	   modified by equipped items.
+	   Result of attack is not displayed directly; instead, CombatEvent
+	   is emitted, and listeners (like message log) handle it.
+	   Broken weapons deal half damage. If attack dealt any damage,
+	   HitStatuses of attacker, and of its weapon (unless it is broken),
+	   are applied to target.
+	   Every attack wears attacker's weapon; every hit wears target's armor.
+	   Attacks are noisy - shots even more than melee attacks.
+	   Melee attacks against sleeping, or unaware targets (sneak attacks)
+	   deal bonus damage - check stealth.go. */
+	c.SpendEnergy(ActionCostAttack)
+	if c.DistanceTo(t.X, t.Y) > 1 {
+		EmitNoise(c.X, c.Y, NoiseShot, c)
+	} else {
+		EmitNoise(c.X, c.Y, NoiseAttack, c)
+	}
+	weapon := c.ActiveWeapon(t)
+	attack := c.EffectiveAttack()
+	att := RandInt(attack)      //basic attack roll
+	att2 := 0                   //critical bonus
+	def := t.EffectiveDefense() //opponent's defense
+	dmg := 0                    //dmg delivered
+	crit := false               //was it critical hit?
+	if att == attack {          //critical hit!
 		crit = true
-		att2 = RandInt(c.Attack)
+		att2 = RandInt(attack)
 	}
 	switch {
 	case att < def: // Attack score if lower than target defense.
-		if crit == false {
-			AddMessage("Attack deflected!")
-		} else {
+		if crit == true {
 			dmg = att2 // Critical hit, but against heavily armored enemy.
-			AddMessage("Critical hit! <heavily armored enemy>")
 		}
 	case att == def: // Attack score is equal to target defense.
 		if crit == false {
 			dmg = 1 // It's just a scratch...
-			AddMessage("Attack successful, but it is just a scratch...")
 		} else {
 			dmg = att
-			AddMessage("Critical hit, but it barely bypassed opponent's armor.")
 		}
 	case att > def: // Attack score is bigger than target defense.
 		if crit == false {
 			dmg = att
-			AddMessage("Successful attack!")
 		} else {
 			dmg = att + att2 // Critical attack!
-			AddMessage("Critical attack!")
 		}
 	}
+	dmg = WeaponDamage(weapon, dmg)
+	sneak := false
+	if bonus := c.SneakAttackBonus(t); bonus > 0 && dmg > 0 {
+		dmg += bonus
+		sneak = true
+	}
+	event := CombatEvent{Attacker: c, Target: t, AttackerName: c.Name,
+		TargetName: t.Name, Roll: att, CritRoll: att2, Defense: def,
+		Damage: dmg, Crit: crit, Sneak: sneak}
+	alive := t.HPCurrent > 0
 	t.TakeDamage(dmg, o)
+	event.Kill = alive == true && t.HPCurrent <= 0
+	EmitCombatEvent(event, b, o, cs)
+	if dmg > 0 && t.HPCurrent > 0 {
+		t.AddStatuses(c.HitStatuses, c)
+		if weapon != nil && weapon.IsBroken() == false {
+			t.AddStatuses(weapon.HitStatuses, c)
+		}
+		t.WearArmor(WearPerUse)
+	}
+	c.WearItem(weapon, WearPerUse)
+}
+
+func (c *Creature) ActiveWeapon(t *Creature) *Object {
+	/* Method ActiveWeapon returns weapon that receiver uses to attack
+	   target t. Adjacent targets are attacked by melee weapon; others
+	   by primary ranged weapon or, if not present, secondary one.
+	   Returns nil if receiver does not use any weapon. */
+	if c.DistanceTo(t.X, t.Y) <= 1 {
+		return c.Equipment[SlotWeaponMelee]
+	}
+	return c.RangedWeapon()
+}
+
+func (c *Creature) RangedWeapon() *Object {
+	/* Method RangedWeapon returns primary ranged weapon of receiver or,
+	   if not present, secondary one. Returns nil if receiver does not
+	   have any ranged weapon equipped. */
+	if c.Equipment[SlotWeaponPrimary] != nil {
+		return c.Equipment[SlotWeaponPrimary]
+	}
+	return c.Equipment[SlotWeaponSecondary]
 }
 
 func (c *Creature) TakeDamage(dmg int, o *Objects) {
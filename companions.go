@@ -0,0 +1,237 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+)
+
+const (
+	// Orders that player may give to companions.
+	OrderFollow = "follow"
+	OrderStay   = "stay"
+	OrderAttack = "attack"
+)
+
+const (
+	// Companions try to stay within FollowDistance from player,
+	// and do not chase enemies farther than FollowLeash from player.
+	FollowDistance = 2
+	FollowLeash    = 8
+)
+
+// Orders is list of orders, as displayed in command menu.
+var Orders = []string{OrderFollow, OrderStay, OrderAttack}
+
+func Party(cs Creatures) Creatures {
+	/* Function Party returns all living companions of player.
+	   Party is saved together with other Creatures, and their
+	   orders are part of Creature, so they survive saving and
+	   loading. There are no levels (yet); when they are added,
+	   Party is what should be moved to new level with player. */
+	var party = Creatures{}
+	for _, v := range cs {
+		if v.AIType == CompanionAI && v.HPCurrent > 0 {
+			party = append(party, v)
+		}
+	}
+	return party
+}
+
+func CreatureIndex(c *Creature, cs Creatures) int {
+	/* Function CreatureIndex returns index of c in slice of Creatures,
+	   or -1 if it is not there. Creatures are never removed from
+	   slice (dead ones become corpses), so index may be used as
+	   identifier that survives saving - unlike pointer. */
+	for i, v := range cs {
+		if v == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Creature) OrderedTarget(cs Creatures) *Creature {
+	/* Method OrderedTarget returns Creature that receiver was ordered
+	   to attack, or nil if there is no such order, or the target
+	   is dead already. */
+	if c.Order != OrderAttack || c.OrderTarget < 0 || c.OrderTarget >= len(cs) {
+		return nil
+	}
+	t := cs[c.OrderTarget]
+	if t == c || t.HPCurrent <= 0 {
+		return nil
+	}
+	return t
+}
+
+func (c *Creature) GiveOrder(order string, t *Creature, cs Creatures) {
+	/* Method GiveOrder sets current order of receiver.
+	   Target is used only by attack order; it may be nil otherwise. */
+	c.Order = order
+	c.OrderTarget = -1
+	switch order {
+	case OrderFollow:
+		AddMessage("The " + c.Name + " follows you.")
+	case OrderStay:
+		AddMessage("The " + c.Name + " stays.")
+	case OrderAttack:
+		c.OrderTarget = CreatureIndex(t, cs)
+		c.Awareness = AwarenessAlert
+		AddMessage("The " + c.Name + " attacks the " + t.Name + ".")
+	}
+}
+
+func (p *Creature) CommandMenu(b Board, o Objects, cs Creatures) bool {
+	/* Method CommandMenu lets player choose companion (or the whole
+	   party), and order: follow, stay, or attack target. Target of
+	   attack is chosen using AimCursor. Giving orders does not
+	   take turn. */
+	turnSpent := false
+	party := Party(cs)
+	if len(party) == 0 {
+		AddMessage("You have no companions.")
+		return turnSpent
+	}
+	chosen := party
+	if len(party) > 1 {
+		var names = []string{}
+		for _, v := range party {
+			names = append(names, v.Name)
+		}
+		names = append(names, "everyone")
+		option := ChooseOption("Command:", names)
+		if option < 0 {
+			return turnSpent
+		}
+		if option < len(party) {
+			chosen = Creatures{party[option]}
+		}
+	}
+	option := ChooseOption("Order:", Orders)
+	if option < 0 {
+		return turnSpent
+	}
+	var target *Creature
+	if Orders[option] == OrderAttack {
+		target = p.ChooseEnemy(b, o, cs)
+		if target == nil {
+			return turnSpent
+		}
+	}
+	for _, v := range chosen {
+		v.GiveOrder(Orders[option], target, cs)
+	}
+	return turnSpent
+}
+
+func ChooseOption(header string, options []string) int {
+	/* Function ChooseOption prints menu, and waits for player to
+	   choose one of options. Returns index of chosen option, or
+	   -1 if player cancelled menu by Escape. */
+	for {
+		PrintMenu(UIPosX, UIPosY, header, options)
+		key := ReadInput()
+		if key == blt.TK_ESCAPE {
+			return -1
+		}
+		option := KeyToOrder(key)
+		if option >= 0 && option < len(options) {
+			return option
+		}
+	}
+}
+
+func (p *Creature) ChooseEnemy(b Board, o Objects, cs Creatures) *Creature {
+	/* Method ChooseEnemy lets player choose target of attack order,
+	   using AimCursor. Only Creatures that are not allied with
+	   player are valid targets (FindTargets skips allies).
+	   Returns nil if player cancelled, or chose invalid target. */
+	targets := p.FindTargets(FOVLength, b, cs, o)
+	if len(targets) == 0 {
+		AddMessage("There are no enemies in sight.")
+		return nil
+	}
+	x, y, confirmed := p.AimCursor(b, o, cs, targets)
+	if confirmed == false {
+		return nil
+	}
+	t := GetAliveCreatureFromTile(x, y, cs)
+	if t == nil || p.Relation(t) == RelationAllied {
+		AddMessage("There is nobody to attack.")
+		return nil
+	}
+	return t
+}
+
+func (p *Creature) SwapPlaces(c *Creature) bool {
+	/* Method SwapPlaces is called when player bumps into companion.
+	   Player and companion swap places, instead of fighting. */
+	p.X, c.X = c.X, p.X
+	p.Y, c.Y = c.Y, p.Y
+	p.SpendEnergy(ActionCostMove)
+	return true
+}
+
+func BehaviourFollowHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourFollowHandler executes orders of player.
+	   Player is the first element of Creatures.
+	   - stay: Creature does not move (nodes placed before follow,
+	     like attack or shoot, still may fight enemies in reach);
+	   - attack: node fails, so next nodes (like approach) chase
+	     ordered target; when target dies, order changes to follow;
+	   - follow: Creature moves towards player, if it is farther
+	     than Distance (default FollowDistance); it fights visible
+	     enemies instead, unless they lead it too far from player. */
+	leader := cs[0]
+	if leader == c || leader.HPCurrent <= 0 {
+		return false
+	}
+	switch c.Order {
+	case OrderStay:
+		return true
+	case OrderAttack:
+		if c.OrderedTarget(cs) != nil {
+			return false
+		}
+		c.Order, c.OrderTarget = OrderFollow, -1
+	}
+	distance := n.Distance
+	if distance <= 0 {
+		distance = FollowDistance
+	}
+	dist := c.DistanceTo(leader.X, leader.Y)
+	if AITarget(c, b, cs) != nil && c.Awareness == AwarenessAlert && dist <= FollowLeash {
+		return false
+	}
+	if dist <= distance {
+		return false
+	}
+	c.MoveTowards(b, cs, leader.X, leader.Y, MovementStyle(n))
+	return true
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// Panes of transfer menu.
+	PaneContainer = iota
+	PaneInventory
+)
+
+func (o *Object) FillContainer() error {
+	/* Method FillContainer creates Objects listed (as json file names)
+	   in ContentsFiles of receiver, and puts them into its Contents.
+	   Then it rolls LootTable of receiver (if any) and adds rolled
+	   items to Contents as well. */
+	var err error
+	for _, v := range o.ContentsFiles {
+		item, err2 := NewObject(o.X, o.Y, v)
+		if err2 != nil {
+			err = err2
+		}
+		o.AddToContents(item)
+	}
+	if o.LootTable != "" {
+		loot, err2 := RollLoot(o.LootTable, CurrentDepth, o.X, o.Y)
+		if err2 != nil {
+			err = err2
+		}
+		for _, v := range loot {
+			o.AddToContents(v)
+		}
+	}
+	return err
+}
+
+func (o *Object) AddToContents(item *Object) {
+	/* Method AddToContents puts item into receiver's Contents.
+	   Stackable items are merged with stack of the same kind,
+	   if present. Containers have unlimited capacity. */
+	for _, v := range o.Contents {
+		if v.StacksWith(item) {
+			v.Quantity += item.Quantity
+			return
+		}
+	}
+	o.Contents = append(o.Contents, item)
+}
+
+func (o *Object) RemoveFromContents(index int) *Object {
+	/* Method RemoveFromContents removes item at index from receiver's
+	   Contents (whole stack), and returns it. */
+	item := o.Contents[index]
+	copy(o.Contents[index:], o.Contents[index+1:])
+	o.Contents[len(o.Contents)-1] = nil
+	o.Contents = o.Contents[:len(o.Contents)-1]
+	return item
+}
+
+func NewCorpse(c *Creature) *Object {
+	/* Function NewCorpse creates container that holds everything that
+	   Creature had in its Equipment and Inventory. It is called during
+	   Creature's death, so remains can be looted later.
+	   Returns nil if Creature did not carry anything. */
+	var contents = Objects{}
+	for i, v := range c.Equipment {
+		if v != nil {
+			contents = append(contents, v)
+			c.Equipment[i] = nil
+		}
+	}
+	contents = append(contents, c.Inventory...)
+	c.Inventory = Objects{}
+	if len(contents) == 0 {
+		return nil
+	}
+	corpse := &Object{}
+	corpse.Layer = ObjectsLayer
+	corpse.X, corpse.Y = c.X, c.Y
+	corpse.Char = CorpseChar
+	corpse.Name = c.Name
+	corpse.Color, corpse.ColorDark = c.Color, c.ColorDark
+	corpse.Slot = SlotNA
+	corpse.Quantity = 1
+	corpse.Container = true
+	for _, v := range contents {
+		corpse.AddToContents(v)
+	}
+	return corpse
+}
+
+func FindContainers(x, y int, o Objects) Objects {
+	/* Function FindContainers returns all containers that lie on
+	   x, y tile, or on one of adjacent tiles. Containers often
+	   block movement (think about wardrobes), so they can not
+	   be looted from the same tile. */
+	var containers = Objects{}
+	for _, v := range o {
+		if v.Container == false {
+			continue
+		}
+		if AbsoluteValue(v.X-x) <= 1 && AbsoluteValue(v.Y-y) <= 1 {
+			containers = append(containers, v)
+		}
+	}
+	return containers
+}
+
+func (p *Creature) Loot(o *Objects) bool {
+	/* Method Loot is called when player wants to loot something.
+	   If there is more than one container nearby, player has to
+	   choose one of them from the list; then TransferMenu is shown.
+	   Returns true if any item was transferred. */
+	turnSpent := false
+	containers := FindContainers(p.X, p.Y, *o)
+	if len(containers) == 0 {
+		AddMessage("There is nothing to loot here.")
+		return turnSpent
+	}
+	container := containers[0]
+	if len(containers) > 1 {
+		for {
+			PrintInventoryMenu(UIPosX, UIPosY, "Loot:", containers)
+			option := KeyToOrder(ReadInput())
+			if option == KeyToOrder(blt.TK_ESCAPE) {
+				return turnSpent
+			} else if option >= 0 && option < len(containers) {
+				container = containers[option]
+				break
+			}
+		}
+	}
+	turnSpent = p.TransferMenu(container)
+	return turnSpent
+}
+
+func (p *Creature) TransferMenu(container *Object) bool {
+	/* Method TransferMenu shows contents of container or player's
+	   Inventory - TAB key switches between these two panes.
+	   Choosing item moves it to the other side: from container
+	   to Inventory, or from Inventory to container.
+	   Returns true if any item was transferred. */
+	turnSpent := false
+	pane := PaneContainer
+	for {
+		if pane == PaneContainer {
+			header := container.Name + "\n[[TAB]] inventory"
+			PrintInventoryMenu(UIPosX, UIPosY, header, container.Contents)
+		} else {
+			header := "Inventory (" + strconv.Itoa(len(p.Inventory)) + "/" +
+				strconv.Itoa(InventoryCapacity) + ")\n[[TAB]] " + container.Name
+			PrintInventoryMenu(UIPosX, UIPosY, header, p.Inventory)
+		}
+		key := ReadInput()
+		if key == blt.TK_ESCAPE {
+			break
+		} else if key == blt.TK_TAB {
+			if pane == PaneContainer {
+				pane = PaneInventory
+			} else {
+				pane = PaneContainer
+			}
+			continue
+		}
+		option := KeyToOrder(key)
+		if pane == PaneContainer && option >= 0 && option < len(container.Contents) {
+			if p.TakeFromContainer(container, option) == true {
+				turnSpent = true
+			}
+		} else if pane == PaneInventory && option >= 0 && option < len(p.Inventory) {
+			if p.PutIntoContainer(container, option) == true {
+				turnSpent = true
+			}
+		}
+	}
+	return turnSpent
+}
+
+func (c *Creature) TakeFromContainer(container *Object, index int) bool {
+	/* Method TakeFromContainer moves item (whole stack) from
+	   container to receiver's Inventory. It fails if there is
+	   no place in Inventory. */
+	item := container.Contents[index]
+	if c.CanCarry(item) == false {
+		if c.AIType == PlayerAI {
+			AddMessage("Your inventory is full.")
+		}
+		return false
+	}
+	container.RemoveFromContents(index)
+	c.AddToInventory(item)
+	if c.AIType == PlayerAI {
+		AddMessage("You took " + FormatObjectName(item) + ".")
+	}
+	c.SpendEnergy(ActionCostPickUp)
+	return true
+}
+
+func (c *Creature) PutIntoContainer(container *Object, index int) bool {
+	/* Method PutIntoContainer moves item (whole stack) from
+	   receiver's Inventory to container. */
+	item := c.RemoveFromInventory(index)
+	container.AddToContents(item)
+	if c.AIType == PlayerAI {
+		AddMessage("You put " + FormatObjectName(item) + " into " +
+			container.Name + ".")
+	}
+	c.SpendEnergy(ActionCostDrop)
+	return true
+}
+
+func PlaceContainers(m *MapJson, symbols [][]string) (Objects, error) {
+	/* Function PlaceContainers creates container Objects on every tile
+	   which symbol is listed in Containers legend of json map.
+	   "symbols" is 2d slice of symbols, used during loading map,
+	   indexed in the same way as Board. */
+	var err error
+	var containers = Objects{}
+	for x := 0; x < len(symbols); x++ {
+		for y := 0; y < len(symbols[x]); y++ {
+			path, ok := m.Containers[symbols[x][y]]
+			if ok == false {
+				continue
+			}
+			container, err2 := NewObject(x, y, path)
+			if err2 != nil {
+				err = err2
+			}
+			if container.Container == false {
+				txt := ContainerError(path)
+				err = errors.New("Object placed as container is not container." + txt)
+				fmt.Println(err)
+				continue
+			}
+			containers = append(containers, container)
+		}
+	}
+	return containers, err
+}
@@ -46,6 +46,10 @@ const (
 
 	StrInventory = "INVENTORY"
 	StrEquipment = "EQUIPMENT"
+	StrLoot      = "LOOT"
+
+	StrCommand = "COMMAND"
+	StrSneak   = "SNEAK"
 )
 
 var Actions = []string{
@@ -59,6 +63,9 @@ var Actions = []string{
 	StrPickup,
 	StrInventory,
 	StrEquipment,
+	StrLoot,
+	StrCommand,
+	StrSneak,
 }
 
 var CommandKeys = map[int]string{
@@ -72,6 +79,9 @@ var CommandKeys = map[int]string{
 	blt.TK_G:     StrPickup,
 	blt.TK_I:     StrInventory,
 	blt.TK_E:     StrEquipment,
+	blt.TK_O:     StrLoot,
+	blt.TK_C:     StrCommand,
+	blt.TK_Z:     StrSneak,
 }
 
 /* Place to store customized controls scheme,
@@ -104,9 +114,15 @@ func Command(com string, p *Creature, b *Board, c *Creatures, o *Objects) bool {
 	case StrPickup:
 		turnSpent = p.PickUp(o)
 	case StrInventory:
-		turnSpent = p.InventoryMenu(o)
+		turnSpent = p.InventoryMenu(*b, o, *c)
 	case StrEquipment:
-		turnSpent = p.EquipmentMenu(o)
+		turnSpent = p.EquipmentMenu(*b, o, *c)
+	case StrLoot:
+		turnSpent = p.Loot(o)
+	case StrCommand:
+		turnSpent = p.CommandMenu(*b, *o, *c)
+	case StrSneak:
+		turnSpent = p.ToggleSneak()
 	}
 	return turnSpent
 }
@@ -0,0 +1,223 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"strconv"
+)
+
+const (
+	// Durability lost by weapon on every attack,
+	// and by armor on every hit taken.
+	WearPerUse = 1
+
+	// Damage dealt with broken weapon is divided by this value.
+	BrokenWeaponDivisor = 2
+)
+
+var ArmorSlots = []int{
+	// Equipment slots that wear out when Creature is hit.
+	SlotHead,
+	SlotBody,
+	SlotHands,
+	SlotFeet,
+	SlotShield,
+}
+
+func (o *Object) IsBroken() bool {
+	/* Method IsBroken returns true if receiver has durability,
+	   and it dropped to 0. Items without DurabilityMax never break. */
+	return o.DurabilityMax > 0 && o.Durability <= 0
+}
+
+func (o *Object) IsDamaged() bool {
+	/* Method IsDamaged returns true if receiver may be repaired. */
+	return o.DurabilityMax > 0 && o.Durability < o.DurabilityMax
+}
+
+func (o *Object) Modifier(value int) int {
+	/* Method Modifier returns effective value of one of receiver's
+	   modifiers. Broken items give only half of their bonuses
+	   (but full penalties). */
+	if o.IsBroken() == true && value > 0 {
+		return value / 2
+	}
+	return value
+}
+
+func WeaponDamage(weapon *Object, damage int) int {
+	/* Function WeaponDamage returns damage dealt with weapon:
+	   broken weapons deal only half of damage. Weapon may be nil
+	   (ie unarmed attacks); then, damage is not changed. */
+	if weapon != nil && weapon.IsBroken() == true {
+		return damage / BrokenWeaponDivisor
+	}
+	return damage
+}
+
+func (c *Creature) WearItem(o *Object, amount int) {
+	/* Method WearItem decreases durability of item, owned by receiver.
+	   Player is informed when item breaks. As broken items give smaller
+	   bonuses, HP of receiver is clamped. */
+	if o == nil || o.DurabilityMax <= 0 || o.IsBroken() == true {
+		return
+	}
+	o.Durability -= amount
+	if o.Durability <= 0 {
+		o.Durability = 0
+		if c.AIType == PlayerAI {
+			AddMessage("Your " + DisplayName(o) + " breaks!")
+		}
+		c.ClampHP()
+	}
+}
+
+func (c *Creature) WearArmor(amount int) {
+	/* Method WearArmor is called when receiver is hit. One of armor
+	   pieces that may wear out is chosen randomly, and loses durability. */
+	var armor = Objects{}
+	for _, slot := range ArmorSlots {
+		o := c.Equipment[slot]
+		if o != nil && o.DurabilityMax > 0 && o.IsBroken() == false {
+			armor = append(armor, o)
+		}
+	}
+	if len(armor) == 0 {
+		return
+	}
+	c.WearItem(armor[RandInt(len(armor)-1)], amount)
+}
+
+func (o *Object) Repair(amount int) {
+	/* Method Repair restores amount of receiver's durability.
+	   Amount that is smaller than 1 means full repair. */
+	if amount <= 0 || o.Durability+amount > o.DurabilityMax {
+		o.Durability = o.DurabilityMax
+		return
+	}
+	o.Durability += amount
+}
+
+func GetDamaged(c *Creature) Objects {
+	/* Function GetDamaged returns every item owned by Creature
+	   (both equipped and in Inventory) that may be repaired. */
+	var items = Objects{}
+	for _, v := range c.Equipment {
+		if v != nil && v.IsDamaged() == true {
+			items = append(items, v)
+		}
+	}
+	for _, v := range c.Inventory {
+		if v.IsDamaged() == true {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+func FindRepairKit(c *Creature) *Object {
+	/* Function FindRepairKit returns first item from Creature's
+	   Inventory that has repair effect, or nil if there is none. */
+	for _, v := range c.Inventory {
+		for _, e := range v.Effects {
+			if e.Type == EffectRepair {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Creature) RepairMenu(power int) {
+	/* Method RepairMenu lets player choose one damaged item,
+	   and repairs it. Escape cancels the menu; in that case,
+	   nothing is repaired. */
+	items := GetDamaged(p)
+	if len(items) == 0 {
+		AddMessage("You have nothing to repair.")
+		return
+	}
+	for {
+		PrintInventoryMenu(UIPosX, UIPosY, "Repair:", items)
+		key := ReadInput()
+		if key == blt.TK_ESCAPE {
+			return
+		}
+		option := KeyToOrder(key)
+		if option >= 0 && option < len(items) {
+			items[option].Repair(power)
+			AddMessage("You repaired " + DisplayName(items[option]) + ".")
+			return
+		}
+	}
+}
+
+func (p *Creature) RepairWithKit(o *Object) (bool, error) {
+	/* Method RepairWithKit is called when player chooses "repair" action
+	   in equipment menu. It finds repair kit in Inventory, repairs
+	   item, and consumes kit. Returns true if item was repaired. */
+	kit := FindRepairKit(p)
+	if kit == nil {
+		AddMessage("You do not have repair kit.")
+		return false, nil
+	}
+	power := 0
+	for _, e := range kit.Effects {
+		if e.Type == EffectRepair {
+			power = e.Power
+		}
+	}
+	o.Repair(power)
+	p.SpendEnergy(ActionCostUse)
+	AddMessage("You repaired " + DisplayName(o) + ".")
+	err := DestroyItem(kit, p)
+	return true, err
+}
+
+func FormatDurability(o *Object) string {
+	/* Function FormatDurability returns durability of Object
+	   as short string, like "{7/10}", or "{broken}". Items
+	   without durability return empty string. */
+	if o.DurabilityMax <= 0 {
+		return ""
+	}
+	if o.IsBroken() == true {
+		return " {broken}"
+	}
+	return " {" + strconv.Itoa(o.Durability) + "/" +
+		strconv.Itoa(o.DurabilityMax) + "}"
+}
+
+func EffectRepairHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectRepairHandler lets player repair one item. */
+	if user.AIType != PlayerAI {
+		return
+	}
+	user.RepairMenu(e.Power)
+}
@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"errors"
+)
+
+const (
+	// Types of effects that items may have.
+	// Every type has to be registered in EffectHandlers.
+	EffectHeal        = "heal"
+	EffectDamage      = "damage"
+	EffectTeleport    = "teleport"
+	EffectRevealMap   = "reveal_map"
+	EffectStatus      = "status"
+	EffectRestoreAmmo = "restore_ammo"
+	EffectIdentify    = "identify"
+	EffectRepair      = "repair"
+)
+
+const (
+	// Use cases of items from older saves; check MigrateUse.
+	UseNA = iota
+
+	UseHeal
+)
+
+const (
+	// Power of heal effect that replaces UseHeal.
+	UseHealPower = 100
+)
+
+const (
+	// Number of attempts to find free tile during teleport.
+	TeleportAttempts = 100
+)
+
+type Effect struct {
+	/* Effect is single, parameterised record that describes what happens
+	   when item is used (or shattered). Effects are declared in json
+	   files, as list, and every one of them is executed in order.
+	   Meaning of parameters depends on Type:
+	   - heal: Power is number of restored HP;
+	   - damage: Power is damage dealt in Radius around target point;
+	   - teleport: moves target to random free tile;
+	   - reveal_map: marks every tile of map as explored;
+	   - status: applies Status of type Status, with Duration and Power;
+	   - restore_ammo: Power is number of restored ammo (0 means
+	     full reload) of target's ranged weapons;
+	   - identify: lets player identify one item;
+	   - repair: lets player repair one item; Power is restored
+	     durability (0 means full repair). */
+	Type     string
+	Power    int
+	Radius   int
+	Duration int
+	Status   int
+}
+
+// Effects is list of all Effects of single item.
+type Effects []Effect
+
+// EffectHandler is function that executes single Effect.
+// User is Creature that used (or threw) item; target is Creature
+// affected (it may be nil, ie if thrown item missed), and x, y
+// is point of impact.
+type EffectHandler func(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures)
+
+// EffectHandlers is registry of all effect types.
+var EffectHandlers = map[string]EffectHandler{}
+
+func InitializeEffects() {
+	/* Function InitializeEffects registers handlers of all effect types.
+	   New types of effect should be added here. It is called during
+	   initialization of the game, before loading any data from json. */
+	RegisterEffect(EffectHeal, EffectHealHandler)
+	RegisterEffect(EffectDamage, EffectDamageHandler)
+	RegisterEffect(EffectTeleport, EffectTeleportHandler)
+	RegisterEffect(EffectRevealMap, EffectRevealMapHandler)
+	RegisterEffect(EffectStatus, EffectStatusHandler)
+	RegisterEffect(EffectRestoreAmmo, EffectRestoreAmmoHandler)
+	RegisterEffect(EffectIdentify, EffectIdentifyHandler)
+	RegisterEffect(EffectRepair, EffectRepairHandler)
+}
+
+func RegisterEffect(effectType string, handler EffectHandler) {
+	/* Function RegisterEffect adds handler to EffectHandlers registry. */
+	EffectHandlers[effectType] = handler
+}
+
+func ValidateEffects(effects Effects) error {
+	/* Function ValidateEffects checks if every Effect in slice
+	   has registered type; status effects have to describe
+	   valid Status as well. It is used during creating
+	   Objects from json files. */
+	var err error
+	for _, v := range effects {
+		if _, ok := EffectHandlers[v.Type]; ok == false {
+			txt := EffectError(v.Type)
+			err = errors.New("Effect has unknown type." + txt)
+			continue
+		}
+		if v.Type == EffectStatus {
+			if errStatus := ValidateStatuses(Statuses{v.ToStatus()}); errStatus != nil {
+				err = errStatus
+			}
+		}
+	}
+	return err
+}
+
+func (o *Object) MigrateUse() {
+	/* Method MigrateUse converts use case of item loaded from older
+	   save (when items had single Use instead of list of Effects)
+	   into Effects, so old consumables still work. */
+	if o.Use == UseHeal && len(o.Effects) == 0 {
+		o.Effects = Effects{Effect{Type: EffectHeal, Power: UseHealPower}}
+	}
+	o.Use = UseNA
+}
+
+func (e Effect) ToStatus() Status {
+	/* Method ToStatus converts status effect to Status. */
+	return Status{Type: e.Status, Duration: e.Duration, Power: e.Power}
+}
+
+func (o *Object) ApplyEffects(user, target *Creature, x, y int, b Board,
+	objs *Objects, cs Creatures) error {
+	/* Method ApplyEffects executes all Effects of receiver, in order.
+	   Every Effect is resolved by handler registered in EffectHandlers.
+	   Returns error if receiver has no effects, or if any of them
+	   is of unknown type. */
+	var err error
+	if len(o.Effects) == 0 {
+		txt := UseItemError()
+		err = errors.New("Item has no effects specified." + txt)
+		return err
+	}
+	for _, v := range o.Effects {
+		handler, ok := EffectHandlers[v.Type]
+		if ok == false {
+			txt := EffectError(v.Type)
+			err = errors.New("Effect has unknown type." + txt)
+			continue
+		}
+		handler(v, user, target, x, y, b, objs, cs)
+	}
+	return err
+}
+
+func EffectHealHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectHealHandler restores Power HP of target,
+	   up to its effective max HP. */
+	if target == nil || target.HPCurrent <= 0 {
+		return
+	}
+	target.HPCurrent += e.Power
+	target.ClampHP()
+	if target.AIType == PlayerAI {
+		AddMessage("You feel better.")
+	}
+}
+
+func EffectDamageHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectDamageHandler creates blast with center in x, y.
+	   If user is in center of blast (ie read scroll), it is not
+	   affected by its own attack. */
+	tiles := BlastTiles(b, x, y, e.Radius)
+	if user.X == x && user.Y == y {
+		var filtered = [][]int{}
+		for _, v := range tiles {
+			if v[0] != x || v[1] != y {
+				filtered = append(filtered, v)
+			}
+		}
+		tiles = filtered
+	}
+	user.AreaAttack(tiles, x, y, e.Radius, e.Power, b, o, cs)
+}
+
+func EffectTeleportHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectTeleportHandler moves target to random tile
+	   that is not blocked, nor occupied by other Creature.
+	   If it fails to find such tile, target stays in place. */
+	if target == nil || target.HPCurrent <= 0 {
+		return
+	}
+	for i := 0; i < TeleportAttempts; i++ {
+		nx, ny := RandInt(MapSizeX-1), RandInt(MapSizeY-1)
+		if b[nx][ny].Blocked == true || GetAliveCreatureFromTile(nx, ny, cs) != nil {
+			continue
+		}
+		target.X, target.Y = nx, ny
+		if target.AIType == PlayerAI {
+			AddMessage("You are teleported.")
+		}
+		break
+	}
+}
+
+func EffectRevealMapHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectRevealMapHandler marks every tile of map as explored. */
+	for i := 0; i < len(b); i++ {
+		for j := 0; j < len(b[i]); j++ {
+			b[i][j].Explored = true
+		}
+	}
+	if user.AIType == PlayerAI {
+		AddMessage("You sense layout of the area.")
+	}
+}
+
+func EffectStatusHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectStatusHandler applies Status to target. */
+	if target == nil || target.HPCurrent <= 0 {
+		return
+	}
+	target.AddStatus(e.ToStatus(), user)
+}
+
+func EffectRestoreAmmoHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectRestoreAmmoHandler reloads every ranged weapon
+	   (that uses ammo) equipped by target. Power is number of
+	   restored ammo; 0 means full reload. */
+	if target == nil || target.HPCurrent <= 0 {
+		return
+	}
+	for _, slot := range []int{SlotWeaponPrimary, SlotWeaponSecondary} {
+		weapon := target.Equipment[slot]
+		if weapon == nil || weapon.AmmoMax <= 0 {
+			continue
+		}
+		if e.Power <= 0 {
+			weapon.Ammo = weapon.AmmoMax
+		} else {
+			weapon.Ammo += e.Power
+			if weapon.Ammo > weapon.AmmoMax {
+				weapon.Ammo = weapon.AmmoMax
+			}
+		}
+		if target.AIType == PlayerAI {
+			AddMessage("You reloaded " + DisplayName(weapon) + ".")
+		}
+	}
+}
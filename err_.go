@@ -125,21 +125,21 @@ func ItemOptionsEmptyError() string {
 	/* Function ItemOptionsEmptyError is helper function that returns string
 	   to error; it is called if object does not have any use/eq properties
 	   set to true. */
-	txt := "\n    <equippable==false, use==UseNA, pickable==false>"
+	txt := "\n    <equippable==false, effects==nil, pickable==false>"
 	return txt
 }
 
 func UseItemError() string {
 	/* Function UseItemError is helper function that returns string to error;
-	   it is called if object is supposed to have use case, but case is wrong. */
-	txt := "\n    <use case expected, but not found>"
+	   it is called if object is supposed to have effects, but has none. */
+	txt := "\n    <effects expected, but not found>"
 	return txt
 }
 
 func ConsumableWithoutUseError() string {
 	/* Function ConsumableWithoutUseError is helper function that returns string
-	   to error; it is called if object has set consumable to true and use to UseNA. */
-	txt := "\n    <expected use case != UseNA or consumable set to false>"
+	   to error; it is called if object has set consumable to true, but has no effects. */
+	txt := "\n    <expected list of effects or consumable set to false>"
 	return txt
 }
 
@@ -258,3 +258,175 @@ func MapMonstersCoordsAiError(coords, ai int, fileName string) string {
 		"\n   aiTypes length: " + strconv.Itoa(ai) + ">"
 	return txt
 }
+
+func MapMonstersDataError(field string, coords, data int, fileName string) string {
+	/* Function MapMonstersDataError works as MapMonstersCoordsAiError,
+	   but for optional monsters' data (like groups); field is name
+	   of json field that has wrong length. */
+	txt := "\n    <file name: " + fileName + "; " +
+		"\n    coords length: " + strconv.Itoa(coords) + "; " +
+		"\n    " + field + " length: " + strconv.Itoa(data) + ">"
+	return txt
+}
+
+func StatusError(statusType, duration int) string {
+	/* Function StatusError is helper function that takes two ints
+	   (type and duration of Status) as arguments, and returns string
+	   to error. Type of Status should be one of values declared in
+	   statuses.go, and its duration should be bigger than 0. */
+	txt := "\n    <status type: " + strconv.Itoa(statusType) + "; " +
+		"duration: " + strconv.Itoa(duration) + ">"
+	return txt
+}
+
+func StackError(stackable, equippable bool, quantity int) string {
+	/* Function StackError is helper function that takes two bools
+	   (Stackable and Equippable properties of Object) and int (its
+	   Quantity) as arguments, and returns string to error.
+	   Quantity of Object should be at least 1, and only stackable items
+	   can have Quantity bigger than 1. For now, stackable items can not
+	   be equipped. */
+	txt := "\n    <stackable: " + strconv.FormatBool(stackable) + "; " +
+		"equippable: " + strconv.FormatBool(equippable) + "; " +
+		"quantity: " + strconv.Itoa(quantity) + ">"
+	return txt
+}
+
+func EffectError(effectType string) string {
+	/* Function EffectError is helper function that takes string (type
+	   of Effect) as argument, and returns string to error. Every type
+	   of Effect should be registered in EffectHandlers (see effects.go). */
+	txt := "\n    <effect type: " + effectType + ">"
+	return txt
+}
+
+func ChargesError(charges, chargesMax, rechargeTime int) string {
+	/* Function ChargesError is helper function that takes three ints
+	   (Charges, ChargesMax and RechargeTime of Object) as arguments,
+	   and returns string to error. Charges should be between 0 and
+	   ChargesMax; only items with charges can be recharged, and items
+	   with charges can not be stackable. */
+	txt := "\n    <charges: " + strconv.Itoa(charges) + "; " +
+		"max charges: " + strconv.Itoa(chargesMax) + "; " +
+		"recharge time: " + strconv.Itoa(rechargeTime) + ">"
+	return txt
+}
+
+func ContainerError(path string) string {
+	/* Function ContainerError is helper function that takes string (json
+	   file of Object) as argument, and returns string to error. Objects
+	   listed in Containers legend of json map should be containers. */
+	txt := "\n    <object file: " + path + "; expected container==true>"
+	return txt
+}
+
+func ContainerPickableError() string {
+	/* Function ContainerPickableError is helper function that returns string
+	   to error; it is called if object has set both container and pickable
+	   to true. */
+	txt := "\n    <container==true, pickable==true>"
+	return txt
+}
+
+func LootRollsError(name string, rollsMin, rollsMax int) string {
+	/* Function LootRollsError is helper function that takes string (name of
+	   loot table) and two ints (minimal and maximal number of rolls) as
+	   arguments, and returns string to error. RollsMin should not be
+	   negative, and should not be bigger than RollsMax. */
+	txt := "\n    <loot table: " + name + "; " +
+		"rolls: " + strconv.Itoa(rollsMin) + "-" + strconv.Itoa(rollsMax) + ">"
+	return txt
+}
+
+func LootWeightError(name string, weight int) string {
+	/* Function LootWeightError is helper function that takes string (name of
+	   loot table) and int (weight of entry) as arguments, and returns string
+	   to error. Weights of entries should not be negative. */
+	txt := "\n    <loot table: " + name + "; " +
+		"weight: " + strconv.Itoa(weight) + ">"
+	return txt
+}
+
+func LootNestingError(name string) string {
+	/* Function LootNestingError is helper function that takes string (name of
+	   loot table) as argument, and returns string to error. Loot tables
+	   should not refer to each other in cycle. */
+	txt := "\n    <loot table: " + name + "; " +
+		"max nesting: " + strconv.Itoa(LootNestingMax) + ">"
+	return txt
+}
+
+func MapLootCoordsTablesError(coords, tables int, fileName string) string {
+	/* Function MapLootCoordsTablesError is helper function that takes two ints
+	   (slice length) and string (name of json file) as arguments, and
+	   returns string to error.
+	   During loading map from json, loot coords should has the same length
+	   as loot tables. */
+	txt := "\n    <file name: " + fileName + "; " +
+		"\n  coords length: " + strconv.Itoa(coords) + "; " +
+		"\n  tables length: " + strconv.Itoa(tables) + ">"
+	return txt
+}
+
+func DurabilityError(durability, durabilityMax int) string {
+	/* Function DurabilityError is helper function that takes two ints
+	   (Durability and DurabilityMax of Object) as arguments, and returns
+	   string to error. Durability should be between 0 and DurabilityMax. */
+	txt := "\n    <durability: " + strconv.Itoa(durability) + "; " +
+		"max durability: " + strconv.Itoa(durabilityMax) + ">"
+	return txt
+}
+
+func PriceError(price, sellPrice int) string {
+	/* Function PriceError is helper function that takes two ints
+	   (Price and SellPrice of Object) as arguments, and returns
+	   string to error. Prices should not be negative. */
+	txt := "\n    <price: " + strconv.Itoa(price) + "; " +
+		"sell price: " + strconv.Itoa(sellPrice) + ">"
+	return txt
+}
+
+func GoldError(gold int) string {
+	/* Function GoldError is helper function that takes int
+	   (Gold of Creature) as argument, and returns string to error.
+	   Amount of gold should not be negative. */
+	txt := "\n    <gold: " + strconv.Itoa(gold) + ">"
+	return txt
+}
+
+func BehaviourError(behaviourType string) string {
+	/* Function BehaviourError is helper function that takes string (type
+	   of Behaviour node) as argument, and returns string to error. Every
+	   type of node should be registered in BehaviourHandlers
+	   (see behaviours.go). */
+	txt := "\n    <behaviour type: " + behaviourType + ">"
+	return txt
+}
+
+func FactionError(faction string) string {
+	/* Function FactionError is helper function that takes string (name
+	   of faction) as argument, and returns string to error. Every faction
+	   should be listed in relationship matrix (see factions.go). */
+	txt := "\n    <faction: " + faction + ">"
+	return txt
+}
+
+func RangeError(weaponRange int) string {
+	/* Function RangeError is helper function that takes int (Range
+	   of Object) as argument, and returns string to error.
+	   Range should not be negative. */
+	txt := "\n    <range: " + strconv.Itoa(weaponRange) + ">"
+	return txt
+}
+
+func PatrolPointError(point []int) string {
+	/* Function PatrolPointError is helper function that takes slice
+	   of ints (waypoint, guard post, or idle area from json map)
+	   as argument, and returns string to error. */
+	txt := "\n    <point:"
+	for _, v := range point {
+		txt = txt + " " + strconv.Itoa(v)
+	}
+	txt = txt + ">"
+	return txt
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+type CombatEvent struct {
+	/* CombatEvent describes single attack: who attacked whom,
+	   what were rolls, and what was the result.
+	   Names are stored separately, because Creature changes
+	   its name after death (to "corpse of...").
+	   Events are emitted by AttackTarget (and AreaAttack, with Area
+	   flag set; and UpdateStatuses, with Status flag set, when
+	   Creature dies from poison or burn) and sent to every listener
+	   subscribed to combat event stream. */
+	Attacker     *Creature
+	Target       *Creature
+	AttackerName string
+	TargetName   string
+	Roll         int
+	CritRoll     int
+	Defense      int
+	Damage       int
+	Crit         bool
+	Kill         bool
+	Area         bool
+	Sneak        bool
+	Status       bool
+}
+
+// CombatListener is function that receives CombatEvents.
+// Board, Objects and Creatures are passed to let listeners
+// check visibility, spawn objects, etc.
+type CombatListener func(e CombatEvent, b Board, o *Objects, cs Creatures)
+
+// CombatListeners holds all subscribers of combat event stream.
+// Message log, statistics, achievements etc. should subscribe
+// by SubscribeCombat instead of being called from combat.go.
+var CombatListeners = []CombatListener{}
+
+func InitializeCombatListeners() {
+	/* Function InitializeCombatListeners subscribes default
+	   listeners to combat event stream. */
+	SubscribeCombat(LogCombatEvent)
+	SubscribeCombat(AwardExperience)
+	SubscribeCombat(ExplodeOnDeath)
+	SubscribeCombat(UpdateRelations)
+	SubscribeCombat(Disturb)
+}
+
+func SubscribeCombat(l CombatListener) {
+	/* Function SubscribeCombat adds listener to combat event stream. */
+	CombatListeners = append(CombatListeners, l)
+}
+
+func EmitCombatEvent(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function EmitCombatEvent sends event to every subscribed listener,
+	   in order of subscription. */
+	for _, l := range CombatListeners {
+		l(e, b, o, cs)
+	}
+}
+
+func LogCombatEvent(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function LogCombatEvent is listener that builds message
+	   for message log from CombatEvent.
+	   Player (ie first element of cs) learns only about things he
+	   is able to see: fights between monsters out of his fov are not
+	   reported at all, and attacker that is not visible is called
+	   "something". */
+	player := cs[0]
+	attackerSeen := e.Attacker == player ||
+		IsInFOV(b, player.X, player.Y, e.Attacker.X, e.Attacker.Y) == true
+	targetSeen := e.Target == player ||
+		IsInFOV(b, player.X, player.Y, e.Target.X, e.Target.Y) == true
+	if attackerSeen == false && targetSeen == false {
+		return
+	}
+	attacker := e.AttackerName
+	you := e.Attacker == player
+	if e.Area == true {
+		attacker = "the blast"
+		you = false
+	} else if e.Status == true {
+		you = false
+	} else if e.Attacker == player {
+		attacker = "you"
+	} else if attackerSeen == false {
+		attacker = "something"
+	}
+	target := e.TargetName
+	if e.Target == player {
+		target = "you"
+	} else if targetSeen == false {
+		target = "something"
+	}
+	var msg string
+	switch {
+	case e.Kill == true:
+		msg = attacker + " " + conjugate("kill", you) + " " + target + "!"
+	case e.Damage == 0:
+		msg = attacker + " " + conjugate("miss", you) + " " + target + "."
+	case e.Sneak == true:
+		msg = attacker + " " + conjugate("backstab", you) + " " + target +
+			" for " + strconv.Itoa(e.Damage) + "!"
+	case e.Crit == true:
+		msg = attacker + " " + conjugate("crit", you) + " " + target +
+			" for " + strconv.Itoa(e.Damage) + "!"
+	default:
+		msg = attacker + " " + conjugate("hit", you) + " " + target +
+			" for " + strconv.Itoa(e.Damage) + "."
+	}
+	AddMessage(strings.ToUpper(msg[:1]) + msg[1:])
+}
+
+func conjugate(verb string, secondPerson bool) string {
+	/* Function conjugate is helper for building combat messages.
+	   It returns verb in form that matches subject - "you hit",
+	   but "monster hits", "monster misses". */
+	if secondPerson == true {
+		return verb
+	}
+	if strings.HasSuffix(verb, "s") {
+		return verb + "es"
+	}
+	return verb + "s"
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"strconv"
+
+	blt "bearlibterminal"
+)
+
+const (
+	// Values for handling experience and leveling.
+	XPPerLevel     = 50 // Base amount of XP, multiplied by level.
+	XPBarLength    = 10
+	LevelHPBonus   = 10
+	LevelStatBonus = 1
+)
+
+const (
+	// Stat improvements available during level up.
+	LevelUpHP      = "+10 max HP"
+	LevelUpAttack  = "+1 attack"
+	LevelUpDefense = "+1 defense"
+)
+
+func ExperienceForLevel(level int) int {
+	/* Function ExperienceForLevel returns total amount of XP that
+	   is necessary to reach specific level. Every level requires
+	   XPPerLevel more experience than previous one, so thresholds
+	   are: 0, 50, 150, 300, etc. */
+	return XPPerLevel * level * (level - 1) / 2
+}
+
+func AwardExperience(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function AwardExperience is listener of combat event stream.
+	   If player killed Creature (also by poison or burn applied
+	   by player), player receives amount of XP specified in
+	   XPValue of that Creature. */
+	if e.Kill == false || e.Attacker.AIType != PlayerAI ||
+		e.Attacker == e.Target {
+		return
+	}
+	e.Attacker.XP += e.Target.XPValue
+}
+
+func (p *Creature) CheckLevelUp() {
+	/* Method CheckLevelUp is called by game loop after player's turn.
+	   For every crossed threshold, it shows level up menu. */
+	for p.XP >= ExperienceForLevel(p.Level+1) {
+		p.LevelUpMenu()
+	}
+}
+
+func (p *Creature) LevelUpMenu() {
+	/* Method LevelUpMenu increases Level of receiver, then lets player
+	   choose stat improvement. Player has to make a choice, so
+	   menu can not be closed by Escape. */
+	p.Level++
+	AddMessage("You reached level " + strconv.Itoa(p.Level) + "!")
+	var options = []string{LevelUpHP, LevelUpAttack, LevelUpDefense}
+	for {
+		PrintMenu(UIPosX, UIPosY, "Level up!", options)
+		option := KeyToOrder(ReadInput())
+		if option < 0 || option >= len(options) {
+			continue
+		}
+		switch options[option] {
+		case LevelUpHP:
+			p.HPMax += LevelHPBonus
+			p.HPCurrent += LevelHPBonus
+		case LevelUpAttack:
+			p.Attack += LevelStatBonus
+		case LevelUpDefense:
+			p.Defense += LevelStatBonus
+		}
+		break
+	}
+	blt.ClearArea(UIPosX, UIPosY, UISizeX, UISizeY)
+}
+
+func FormatExperienceBar(c *Creature) string {
+	/* Function FormatExperienceBar returns XP bar, that shows
+	   progress between current and next level, like:
+	   "XP: [[####      ]]" (brackets are doubled for BearLibTerminal). */
+	current := ExperienceForLevel(c.Level)
+	next := ExperienceForLevel(c.Level + 1)
+	filled := 0
+	if next > current {
+		filled = (c.XP - current) * XPBarLength / (next - current)
+	}
+	if filled < 0 {
+		filled = 0
+	} else if filled > XPBarLength {
+		filled = XPBarLength
+	}
+	bar := "XP: [["
+	for i := 0; i < XPBarLength; i++ {
+		if i < filled {
+			bar += "#"
+		} else {
+			bar += " "
+		}
+	}
+	return bar + "]]"
+}
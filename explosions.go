@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+func BlastTiles(b Board, x, y, radius int) [][]int {
+	/* Function BlastTiles returns coords of all tiles affected by
+	   blast with center in x, y. It works as CastRays (and uses the same
+	   precomputed tables), so blast is occluded the same way as
+	   field of view: rays stop at blocked tiles and at tiles
+	   that block sight. Blocked tiles are not included; tiles that only
+	   block sight (like doors) are.
+	   Every tile is returned only once, in order of casting rays,
+	   therefore result is always the same for the same map. */
+	return castArea(b, x, y, radius, 0, FOVRays)
+}
+
+func ConeTiles(b Board, sx, sy, tx, ty, radius, spread int) [][]int {
+	/* Function ConeTiles works as BlastTiles, but returns only tiles
+	   within cone that starts in source (sx, sy), is directed
+	   at target (tx, ty), and is "spread" degrees wide.
+	   Source tile is not part of cone. */
+	// Rays are cast in (-sin, -cos) direction; see CastRays.
+	direction := math.Atan2(float64(sx-tx), float64(sy-ty)) * 180 / math.Pi
+	from := RoundFloatToInt(direction) - spread/2
+	tiles := castArea(b, sx, sy, radius, from, spread+1)
+	var cone = [][]int{}
+	for _, v := range tiles {
+		if v[0] != sx || v[1] != sy {
+			cone = append(cone, v)
+		}
+	}
+	return cone
+}
+
+func castArea(b Board, sx, sy, radius, from, rays int) [][]int {
+	/* Function castArea is helper function that casts "rays" rays,
+	   starting from angle "from", and collects all tiles they pass.
+	   Angles may be negative or bigger than FOVRays; they are
+	   wrapped. */
+	var tiles = [][]int{{sx, sy}}
+	var visited = map[int]bool{sx*MapSizeY + sy: true}
+	for i := 0; i < rays; i += FOVStep {
+		angle := ((from+i)%FOVRays + FOVRays) % FOVRays
+		rayX := sinBase[angle]
+		rayY := cosBase[angle]
+		x := float64(sx)
+		y := float64(sy)
+		for j := 0; j < radius; j++ {
+			x -= rayX
+			y -= rayY
+			if x < 0 || y < 0 || x > MapSizeX-1 || y > MapSizeY-1 {
+				break
+			}
+			bx, by := RoundFloatToInt(x), RoundFloatToInt(y)
+			if b[bx][by].Blocked == true {
+				break
+			}
+			if visited[bx*MapSizeY+by] == false {
+				visited[bx*MapSizeY+by] = true
+				tiles = append(tiles, []int{bx, by})
+			}
+			if b[bx][by].BlocksSight == true {
+				break
+			}
+		}
+	}
+	return tiles
+}
+
+func AreaDamage(damage, distance, radius int) int {
+	/* Function AreaDamage returns damage dealt to tile at specific
+	   distance from center of explosion. Damage falls off linearly:
+	   center receives full damage, and the farthest tiles -
+	   1 / (radius+1) of it. */
+	if distance > radius {
+		return 0
+	}
+	return damage * (radius + 1 - distance) / (radius + 1)
+}
+
+func (c *Creature) AreaAttack(tiles [][]int, x, y, radius, damage int,
+	b Board, o *Objects, cs Creatures) {
+	/* Method AreaAttack deals damage to every living Creature that
+	   stands on one of tiles. Damage depends on distance between
+	   Creature and x, y (center of explosion, or source of cone).
+	   Receiver is attacker; it may be hurt by its own attack.
+	   Every hit is emitted as CombatEvent, with Area flag set. */
+	for _, tile := range tiles {
+		t := GetAliveCreatureFromTile(tile[0], tile[1], cs)
+		if t == nil {
+			continue
+		}
+		dmg := AreaDamage(damage, DistanceBetween(x, y, t.X, t.Y), radius)
+		event := CombatEvent{Attacker: c, Target: t, AttackerName: c.Name,
+			TargetName: t.Name, Damage: dmg, Area: true}
+		t.TakeDamage(dmg, o)
+		event.Kill = t.HPCurrent <= 0
+		EmitCombatEvent(event, b, o, cs)
+	}
+}
+
+func (c *Creature) Explode(x, y, radius, damage int, b Board, o *Objects,
+	cs Creatures) {
+	/* Method Explode creates blast with center in x, y.
+	   It is used by grenades, fireballs, explosive deaths, etc.
+	   Explosions are very loud. */
+	EmitNoise(x, y, NoiseExplosion, c)
+	tiles := BlastTiles(b, x, y, radius)
+	c.AreaAttack(tiles, x, y, radius, damage, b, o, cs)
+}
+
+func (c *Creature) ConeAttack(tx, ty, radius, spread, damage int, b Board,
+	o *Objects, cs Creatures) {
+	/* Method ConeAttack creates cone (like dragon's breath) that starts
+	   in receiver's position and is directed at tx, ty. */
+	tiles := ConeTiles(b, c.X, c.Y, tx, ty, radius, spread)
+	c.AreaAttack(tiles, c.X, c.Y, radius, damage, b, o, cs)
+}
+
+func DeliverProjectile(sx, sy, tx, ty int, b Board, cs Creatures) (int, int) {
+	/* Function DeliverProjectile uses Brensenham's line to find place
+	   where projectile, fired from sx, sy to tx, ty, will land.
+	   Projectile flies until it hits living Creature (then it stops
+	   on Creature's tile), blocked tile (then it stops on previous
+	   tile), or reaches its target.
+	   It returns coords of impact point. */
+	vec, err := NewBrensenham(sx, sy, tx, ty)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_ = ComputeBrensenham(vec)
+	x, y := sx, sy
+	for i := 0; i < len(vec.TilesX); i++ {
+		nx, ny := vec.TilesX[i], vec.TilesY[i]
+		if nx == sx && ny == sy {
+			continue
+		}
+		if b[nx][ny].Blocked == true {
+			break
+		}
+		x, y = nx, ny
+		if GetAliveCreatureFromTile(nx, ny, cs) != nil {
+			break
+		}
+	}
+	return x, y
+}
+
+func (c *Creature) FireAreaWeapon(weapon *Object, tx, ty int, b Board,
+	o *Objects, cs Creatures) {
+	/* Method FireAreaWeapon is used instead of AttackTarget, if weapon
+	   has area of effect. Cone weapons (ConeSpread > 0) affect cone
+	   directed at target; other weapons fire projectile that explodes
+	   at impact point. As in AttackTarget, weapon wears with every
+	   use, and broken weapon deals half damage. */
+	c.SpendEnergy(ActionCostAttack)
+	damage := WeaponDamage(weapon, weapon.BlastDamage)
+	if weapon.ConeSpread > 0 {
+		c.ConeAttack(tx, ty, weapon.BlastRadius, weapon.ConeSpread,
+			damage, b, o, cs)
+	} else {
+		x, y := DeliverProjectile(c.X, c.Y, tx, ty, b, cs)
+		c.Explode(x, y, weapon.BlastRadius, damage, b, o, cs)
+	}
+	c.WearItem(weapon, WearPerUse)
+}
+
+func ExplodeOnDeath(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function ExplodeOnDeath is listener of combat event stream.
+	   Creatures with DeathBlastRadius bigger than 0 explode after
+	   being killed. Blast of one Creature may kill another one,
+	   and cause chain reaction. */
+	if e.Kill == false || e.Target.DeathBlastRadius <= 0 {
+		return
+	}
+	e.Target.Explode(e.Target.X, e.Target.Y, e.Target.DeathBlastRadius,
+		e.Target.DeathBlastDamage, b, o, cs)
+}
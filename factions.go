@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"fmt"
+)
+
+const (
+	// Path to json file with relationships between factions.
+	FactionsPathJson = "./data/factions/factions.json"
+)
+
+const (
+	// Default factions of Creatures that do not declare one.
+	FactionPlayer   = "player"
+	FactionMonsters = "monsters"
+)
+
+const (
+	// Relationships between factions.
+	RelationAllied  = "allied"
+	RelationNeutral = "neutral"
+	RelationHostile = "hostile"
+)
+
+// Relations is relationship matrix: Relations[a][b] describes
+// attitude of faction a towards faction b. It is loaded from json
+// at the start of the game, may change during the game
+// (ie after attacking neutral Creature), and is stored in save.
+var Relations = map[string]map[string]string{}
+
+func InitializeFactions() {
+	/* Function InitializeFactions reads relationship matrix
+	   from json file. */
+	var relations = map[string]map[string]string{}
+	err := readJson(FactionsPathJson, &relations)
+	if err != nil {
+		fmt.Println(err)
+	}
+	Relations = relations
+}
+
+func (c *Creature) FactionName() string {
+	/* Method FactionName returns faction of receiver. Creatures
+	   without declared faction belong to player's faction
+	   (if controlled by player), or to monsters. */
+	if c.Faction != "" {
+		return c.Faction
+	}
+	if c.AIType == PlayerAI {
+		return FactionPlayer
+	}
+	return FactionMonsters
+}
+
+func FactionRelation(a, b string) string {
+	/* Function FactionRelation returns attitude of faction a towards
+	   faction b. Members of the same faction are always allied;
+	   pairs that are not listed in matrix are neutral. */
+	if a == b {
+		return RelationAllied
+	}
+	relation, ok := Relations[a][b]
+	if ok == false {
+		return RelationNeutral
+	}
+	return relation
+}
+
+func SetRelation(a, b, relation string) {
+	/* Function SetRelation changes attitude of factions a and b
+	   towards each other. */
+	if Relations[a] == nil {
+		Relations[a] = map[string]string{}
+	}
+	if Relations[b] == nil {
+		Relations[b] = map[string]string{}
+	}
+	Relations[a][b] = relation
+	Relations[b][a] = relation
+}
+
+func (c *Creature) Relation(t *Creature) string {
+	/* Method Relation returns attitude of receiver towards t. */
+	return FactionRelation(c.FactionName(), t.FactionName())
+}
+
+func (c *Creature) IsHostile(t *Creature) bool {
+	/* Method IsHostile returns true if receiver is hostile to t. */
+	return c.Relation(t) == RelationHostile
+}
+
+func VisibleHostiles(c *Creature, b Board, cs Creatures) Creatures {
+	/* Function VisibleHostiles returns all living Creatures that
+	   c is hostile to, and that c can see. */
+	var hostiles = Creatures{}
+	for _, v := range cs {
+		if v == c || v.HPCurrent <= 0 || c.IsHostile(v) == false {
+			continue
+		}
+		if IsInFOV(b, c.X, c.Y, v.X, v.Y) == true {
+			hostiles = append(hostiles, v)
+		}
+	}
+	return hostiles
+}
+
+func VisibleHostile(c *Creature, b Board, cs Creatures) *Creature {
+	/* Function VisibleHostile returns the closest living Creature
+	   that c is hostile to, and that c can see.
+	   Returns nil if there is no such Creature. */
+	var target *Creature
+	best := 0
+	for _, v := range VisibleHostiles(c, b, cs) {
+		dist := c.DistanceTo(v.X, v.Y)
+		if target == nil || dist < best {
+			target, best = v, dist
+		}
+	}
+	return target
+}
+
+func UpdateRelations(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function UpdateRelations is listener of combat event stream.
+	   Attacking Creature of neutral faction makes both factions
+	   hostile to each other. */
+	if e.Attacker == nil || e.Target == nil || e.Attacker == e.Target {
+		return
+	}
+	a, t := e.Attacker.FactionName(), e.Target.FactionName()
+	if FactionRelation(t, a) != RelationNeutral {
+		return
+	}
+	SetRelation(a, t, RelationHostile)
+	if e.Attacker.AIType == PlayerAI {
+		AddMessage("The " + t + " are hostile now!")
+	}
+}
@@ -170,7 +170,7 @@ func GetAllStringsFromTile(x, y int, b Board, c Creatures, o Objects) []string {
 	}
 	for _, vo := range o {
 		if vo.X == x && vo.Y == y {
-			s = append(s, vo.Name)
+			s = append(s, DisplayName(vo))
 		}
 	}
 	if len(s) != 0 {
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"fmt"
+)
+
+func GroupMembers(c *Creature, cs Creatures) Creatures {
+	/* Function GroupMembers returns all living Creatures that belong
+	   to the same Group as c (excluding c itself). Creatures without
+	   Group act alone. */
+	var members = Creatures{}
+	if c.Group == "" {
+		return members
+	}
+	for _, v := range cs {
+		if v != c && v.Group == c.Group && v.HPCurrent > 0 {
+			members = append(members, v)
+		}
+	}
+	return members
+}
+
+func (c *Creature) AlertGroup(t *Creature, cs Creatures) {
+	/* Method AlertGroup is called when receiver notices its target.
+	   Every member of its group becomes alert as well (even if it
+	   was asleep), and learns where target is. */
+	for _, v := range GroupMembers(c, cs) {
+		v.Awareness = AwarenessAlert
+		v.Remember(t.X, t.Y)
+	}
+}
+
+func (c *Creature) ShareTarget(t *Creature, b Board, cs Creatures) {
+	/* Method ShareTarget tells position of target to every alert
+	   member of receiver's group that does not see any enemy. */
+	for _, v := range GroupMembers(c, cs) {
+		if v.Awareness == AwarenessAlert && VisibleHostile(v, b, cs) == nil {
+			v.Remember(t.X, t.Y)
+		}
+	}
+}
+
+func (c *Creature) FlankingTile(t *Creature, b Board, cs Creatures) (int, int) {
+	/* Method FlankingTile returns coords of free tile, adjacent to
+	   target, that receiver should go to. Tiles that are closer to
+	   other members of group are left for them, so group surrounds
+	   target instead of queuing in corridor. If there is no free
+	   tile, coords of target are returned. */
+	members := GroupMembers(c, cs)
+	bestX, bestY := t.X, t.Y
+	best := -1
+	for x := t.X - 1; x <= t.X+1; x++ {
+		for y := t.Y - 1; y <= t.Y+1; y++ {
+			if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+				continue
+			}
+			if b[x][y].Blocked == true {
+				continue
+			}
+			if m := GetAliveCreatureFromTile(x, y, cs); m != nil && m != c {
+				continue
+			}
+			dist := c.DistanceTo(x, y)
+			taken := false
+			for _, v := range members {
+				if v.DistanceTo(x, y) < dist {
+					taken = true
+					break
+				}
+			}
+			if taken == true {
+				continue
+			}
+			if best < 0 || dist < best {
+				best, bestX, bestY = dist, x, y
+			}
+		}
+	}
+	return bestX, bestY
+}
+
+func FirstOnLine(sx, sy, tx, ty int, b Board, cs Creatures, o Objects) *Creature {
+	/* Function FirstOnLine returns the first Creature that stands
+	   on line of fire from sx, sy to tx, ty; nil if line is
+	   blocked by something else. */
+	vec, err := NewBrensenham(sx, sy, tx, ty)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_ = ComputeBrensenham(vec)
+	_, _, target, _ := ValidateBrensenham(vec, b, cs, o)
+	return target
+}
+
+func (c *Creature) FindFiringPosition(t *Creature, b Board, cs Creatures,
+	o Objects) (int, int, bool) {
+	/* Method FindFiringPosition looks for adjacent, free tile from
+	   which receiver has clear line of fire to target. Returns
+	   direction of move, and false if there is no such tile. */
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			x, y := c.X+dx, c.Y+dy
+			if (dx == 0 && dy == 0) || x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+				continue
+			}
+			if b[x][y].Blocked == true || GetAliveCreatureFromTile(x, y, cs) != nil {
+				continue
+			}
+			if FirstOnLine(x, y, t.X, t.Y, b, cs, o) == t {
+				return dx, dy, true
+			}
+		}
+	}
+	return 0, 0, false
+}
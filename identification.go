@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// Path to json file with appearances of unidentified items.
+	AppearancesPathJson = "./data/identify/appearances.json"
+)
+
+// Appearances maps real names of items to their appearances
+// (like "potion of strength": "bubbling red potion").
+// It is randomized for every game, and stored in save.
+var Appearances = map[string]string{}
+
+// Identified holds real names of items that player already knows.
+var Identified = map[string]bool{}
+
+// AppearancePools holds all possible appearances, per kind
+// of item (like "potion", "scroll").
+var AppearancePools = map[string][]string{}
+
+func InitializeIdentification(newGame bool) {
+	/* Function InitializeIdentification reads appearances from json file,
+	   and shuffles them, using GameSeed, so every game has different
+	   appearances. If new game starts, all identification data is
+	   cleared; otherwise (ie after loading game) appearances that are
+	   already in use are removed from pools. Then, appearances are
+	   assigned to every kind of items that does not have one yet. */
+	var pools = map[string][]string{}
+	err := readJson(AppearancesPathJson, &pools)
+	if err != nil {
+		fmt.Println(err)
+	}
+	var kinds = []string{}
+	for k := range pools {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds) // Map iteration order is random.
+	r := rand.New(rand.NewSource(GameSeed))
+	for _, k := range kinds {
+		v := pools[k]
+		r.Shuffle(len(v), func(i, j int) { v[i], v[j] = v[j], v[i] })
+	}
+	AppearancePools = pools
+	if newGame == true {
+		Appearances = map[string]string{}
+		Identified = map[string]bool{}
+		AssignAppearances()
+		return
+	}
+	for kind, pool := range AppearancePools {
+		var free = []string{}
+		for _, v := range pool {
+			used := false
+			for _, a := range Appearances {
+				if a == v {
+					used = true
+					break
+				}
+			}
+			if used == false {
+				free = append(free, v)
+			}
+		}
+		AppearancePools[kind] = free
+	}
+	AssignAppearances()
+}
+
+func AssignAppearances() {
+	/* Function AssignAppearances assigns appearance to every object
+	   from ObjectsPathJson that has Kind. Files are read in lexical
+	   order, so appearances depend only on GameSeed - not on order
+	   in which items are found during the game. */
+	files, err := filepath.Glob(ObjectsPathJson + "*.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, f := range files {
+		var item = &Object{}
+		if err := readJson(f, item); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		item.AssignAppearance()
+	}
+}
+
+func (o *Object) IsIdentified() bool {
+	/* Method IsIdentified returns true if player knows what receiver is.
+	   Only items with Kind (like potions and scrolls) may be unidentified. */
+	return o.Kind == "" || Identified[o.Name] == true
+}
+
+func (o *Object) AssignAppearance() {
+	/* Method AssignAppearance assigns appearance to receiver's kind of
+	   items (if it was not assigned before). Appearances are taken from
+	   shuffled pool of receiver's Kind; if pool is empty, generic
+	   appearance ("unknown potion") is used. */
+	if o.Kind == "" {
+		return
+	}
+	if _, ok := Appearances[o.Name]; ok == true {
+		return
+	}
+	pool := AppearancePools[o.Kind]
+	if len(pool) == 0 {
+		Appearances[o.Name] = "unknown " + o.Kind
+		return
+	}
+	Appearances[o.Name] = pool[0]
+	AppearancePools[o.Kind] = pool[1:]
+}
+
+func DisplayName(o *Object) string {
+	/* Function DisplayName returns name of Object as seen by player:
+	   real name of identified items, and appearance of other ones.
+	   Appearances are assigned by InitializeIdentification; items
+	   without appearance are called generically ("unknown potion"). */
+	if o.IsIdentified() == true {
+		return o.Name
+	}
+	if appearance, ok := Appearances[o.Name]; ok == true {
+		return appearance
+	}
+	return "unknown " + o.Kind
+}
+
+func (o *Object) Identify() bool {
+	/* Method Identify marks receiver's kind of items as known.
+	   Returns true if it was not identified before. */
+	if o.IsIdentified() == true {
+		return false
+	}
+	Identified[o.Name] = true
+	return true
+}
+
+func GetUnidentified(c *Creature) Objects {
+	/* Function GetUnidentified returns all unidentified items
+	   from Creature's Inventory. */
+	var items = Objects{}
+	for _, v := range c.Inventory {
+		if v.IsIdentified() == false {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+func (p *Creature) IdentifyMenu() {
+	/* Method IdentifyMenu lets player choose one unidentified item
+	   from Inventory, and identifies it. Escape cancels the menu;
+	   in that case, nothing is identified. */
+	items := GetUnidentified(p)
+	if len(items) == 0 {
+		AddMessage("You have nothing to identify.")
+		return
+	}
+	for {
+		PrintInventoryMenu(UIPosX, UIPosY, "Identify:", items)
+		key := ReadInput()
+		if key == blt.TK_ESCAPE {
+			return
+		}
+		option := KeyToOrder(key)
+		if option >= 0 && option < len(items) {
+			name := DisplayName(items[option])
+			items[option].Identify()
+			AddMessage("The " + name + " is " + items[option].Name + ".")
+			return
+		}
+	}
+}
+
+func EffectIdentifyHandler(e Effect, user, target *Creature, x, y int,
+	b Board, o *Objects, cs Creatures) {
+	/* Function EffectIdentifyHandler lets player identify one item. */
+	if user.AIType != PlayerAI {
+		return
+	}
+	user.IdentifyMenu()
+}
@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	// Maximal nesting of loot tables; protects against cycles.
+	LootNestingMax = 10
+)
+
+// GameSeed is seed of current game. Loot (and map generation) is
+// reproducible under the same seed. It may be passed by -seed flag;
+// otherwise it is chosen randomly when new game starts.
+var GameSeed int64
+
+// LootSource is source of LootRand. It counts numbers drawn,
+// so state of LootRand may be saved, and restored on load.
+var LootSource = &CountingSource{rand.NewSource(1), 0}
+
+// LootRand is random number generator used only for rolling loot,
+// so other random events (like combat) do not change loot.
+var LootRand = rand.New(LootSource)
+
+// MapRand is random number generator used for map generation
+// (ie choosing room layouts).
+var MapRand = rand.New(rand.NewSource(1))
+
+// CurrentDepth is depth of current level. It is set during loading
+// map, and used by loot tables.
+var CurrentDepth int
+
+// LootTables caches loot tables that were already read from json.
+var LootTables = map[string]*LootTable{}
+
+type CountingSource struct {
+	/* CountingSource wraps rand.Source, and counts numbers drawn
+	   from it. Generator may be restored to the same state by
+	   seeding it with the same seed, and skipping Draws numbers. */
+	Source rand.Source
+	Draws  int64
+}
+
+func (s *CountingSource) Int63() int64 {
+	s.Draws++
+	return s.Source.Int63()
+}
+
+func (s *CountingSource) Seed(seed int64) {
+	s.Source.Seed(seed)
+	s.Draws = 0
+}
+
+type LootEntry struct {
+	/* LootEntry is single position in loot table. It refers either
+	   to Item (json file of Object), or to other Table (json file of
+	   LootTable) that will be rolled; entry with neither of them means
+	   "nothing". Weight is relative chance of choosing this entry.
+	   Entry is available only on depths between MinDepth and MaxDepth;
+	   MaxDepth 0 means no upper limit. */
+	Item     string
+	Table    string
+	Weight   int
+	MinDepth int
+	MaxDepth int
+}
+
+type LootTable struct {
+	/* LootTable describes what may drop from monster, or what may be
+	   found in container or on map. Table is rolled between RollsMin
+	   and RollsMax times (ie "2-4 rolls on table"); every roll chooses
+	   one of Entries, with regard to their weights. Guaranteed entries
+	   are always dropped (nested tables are rolled), if depth allows. */
+	RollsMin   int
+	RollsMax   int
+	Guaranteed []LootEntry
+	Entries    []LootEntry
+}
+
+func InitializeGameSeed(seed int64) {
+	/* Function InitializeGameSeed sets GameSeed, then seeds both
+	   MapRand and LootRand with it. Other random events (like
+	   combat rolls) use global random number generator, and are
+	   not affected by seed.
+	   Seed 0 means that new, random seed will be chosen. */
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+	GameSeed = seed
+	MapRand = rand.New(rand.NewSource(GameSeed))
+	SeedLootRand(GameSeed, 0)
+}
+
+func SeedLootRand(seed, draws int64) {
+	/* Function SeedLootRand seeds LootRand with seed, then skips
+	   draws numbers. It is called with 0 draws when new game starts;
+	   when game is loaded, number of draws is read from save, so
+	   generator continues from the point where game was saved. */
+	LootSource.Seed(seed)
+	for i := int64(0); i < draws; i++ {
+		LootSource.Int63()
+	}
+}
+
+func GetLootTable(name string) (*LootTable, error) {
+	/* Function GetLootTable returns loot table read from json file.
+	   Tables are read only once, and stored in LootTables; invalid
+	   tables are not stored, so error is reported every time. */
+	if table, ok := LootTables[name]; ok == true {
+		return table, nil
+	}
+	var table = &LootTable{}
+	err := LootTableFromJson(LootPathJson+name, table)
+	if err != nil {
+		fmt.Println(err)
+		panic(-1)
+	}
+	if table.RollsMin < 0 || table.RollsMax < table.RollsMin {
+		txt := LootRollsError(name, table.RollsMin, table.RollsMax)
+		err = errors.New("Loot table has wrong number of rolls." + txt)
+	}
+	for _, v := range table.Entries {
+		if v.Weight < 0 {
+			txt := LootWeightError(name, v.Weight)
+			err = errors.New("Loot table entry has negative weight." + txt)
+		}
+	}
+	if err == nil {
+		LootTables[name] = table
+	}
+	return table, err
+}
+
+func (e LootEntry) AvailableAt(depth int) bool {
+	/* Method AvailableAt returns true if entry may drop at depth. */
+	if depth < e.MinDepth {
+		return false
+	}
+	if e.MaxDepth > 0 && depth > e.MaxDepth {
+		return false
+	}
+	return true
+}
+
+func RollLoot(name string, depth, x, y int) (Objects, error) {
+	/* Function RollLoot rolls loot table (json file name), and returns
+	   list of new Objects, placed on x, y. Depth is used to filter
+	   entries that are not available on current level. */
+	return rollLootTable(name, depth, x, y, 0)
+}
+
+func rollLootTable(name string, depth, x, y, nesting int) (Objects, error) {
+	/* Function rollLootTable is helper function for RollLoot.
+	   At first, guaranteed entries are resolved; then table is rolled
+	   random number of times. Nested tables are rolled recursively.
+	   Invalid tables are not rolled at all. */
+	var loot = Objects{}
+	if nesting >= LootNestingMax {
+		txt := LootNestingError(name)
+		return loot, errors.New("Loot tables are nested too deeply." + txt)
+	}
+	table, err := GetLootTable(name)
+	if err != nil {
+		return loot, err
+	}
+	for _, v := range table.Guaranteed {
+		if v.AvailableAt(depth) == false {
+			continue
+		}
+		items, err2 := resolveLootEntry(v, depth, x, y, nesting)
+		if err2 != nil {
+			err = err2
+		}
+		loot = append(loot, items...)
+	}
+	rolls := table.RollsMin + LootRand.Intn(table.RollsMax-table.RollsMin+1)
+	for i := 0; i < rolls; i++ {
+		entry, ok := chooseLootEntry(table.Entries, depth)
+		if ok == false {
+			break // Nothing is available at this depth.
+		}
+		items, err2 := resolveLootEntry(entry, depth, x, y, nesting)
+		if err2 != nil {
+			err = err2
+		}
+		loot = append(loot, items...)
+	}
+	return loot, err
+}
+
+func chooseLootEntry(entries []LootEntry, depth int) (LootEntry, bool) {
+	/* Function chooseLootEntry chooses one of entries that are
+	   available at depth, with regard to their weights.
+	   Returns false if there is no entry to choose. */
+	total := 0
+	for _, v := range entries {
+		if v.AvailableAt(depth) == true {
+			total += v.Weight
+		}
+	}
+	if total <= 0 {
+		return LootEntry{}, false
+	}
+	roll := LootRand.Intn(total)
+	for _, v := range entries {
+		if v.AvailableAt(depth) == false {
+			continue
+		}
+		if roll < v.Weight {
+			return v, true
+		}
+		roll -= v.Weight
+	}
+	return LootEntry{}, false
+}
+
+func resolveLootEntry(e LootEntry, depth, x, y, nesting int) (Objects, error) {
+	/* Function resolveLootEntry creates Object described by entry,
+	   or rolls nested table. Empty entry returns no Objects. */
+	if e.Table != "" {
+		return rollLootTable(e.Table, depth, x, y, nesting+1)
+	}
+	if e.Item != "" {
+		item, err := NewObject(x, y, e.Item)
+		return Objects{item}, err
+	}
+	return Objects{}, nil
+}
@@ -28,6 +28,7 @@ package main
 
 import (
 	blt "bearlibterminal"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
@@ -36,11 +37,15 @@ import (
 
 var KeyboardLayout int
 var CustomControls bool
+var AutoPickup []string
 
 var MsgBuf = []string{}
 var LastTarget *Creature
 
+var SeedFlag = flag.Int64("seed", 0, "seed of new game; 0 means random seed")
+
 func main() {
+	flag.Parse()
 	var cells = new(Board)
 	var objs = new(Objects)
 	var actors = new(Creatures)
@@ -64,9 +69,14 @@ func main() {
 			DeleteSaves()
 			break
 		} else {
+			energy := (*actors)[0].Energy
 			turnSpent := Controls(key, (*actors)[0], cells, actors, objs)
 			if turnSpent == true {
-				CreaturesTakeTurn(*cells, *actors, *objs)
+				if (*actors)[0].Energy == energy {
+					(*actors)[0].SpendEnergy(ActionCostWait)
+				}
+				(*actors)[0].CheckLevelUp()
+				CreaturesTakeTurn(*cells, *actors, objs)
 			}
 		}
 	}
@@ -75,11 +85,21 @@ func main() {
 
 func NewGame(b *Board, c *Creatures, o *Objects) {
 	/* Function NewGame initializes game state - creates player, monsters, and game map.
-	   This implementation is generic-placeholder, for testing purposes. */
+	   This implementation is generic-placeholder, for testing purposes.
+	   At first, game seed is initialized, to make map layout and loot
+	   reproducible, and appearances of unidentified items are shuffled.
+	   Relationships between factions are loaded from json. */
+	InitializeGameSeed(*SeedFlag)
+	InitializeIdentification(true)
+	InitializeFactions()
 	player, err := NewPlayer(1, 1)
 	if err != nil {
 		fmt.Println(err)
 	}
+	companion, err := NewCreature(2, 1, "companion.json")
+	if err != nil {
+		fmt.Println(err)
+	}
 	enemy, err := NewCreature(MapSizeX-2, MapSizeY-2, "patherRanged.json")
 	if err != nil {
 		fmt.Println(err)
@@ -98,18 +118,32 @@ func NewGame(b *Board, c *Creatures, o *Objects) {
 	}
 	var enemyEq = EquipmentComponent{Objects{w1, w2, wm}, Objects{}}
 	enemy.EquipmentComponent = enemyEq
-	*c = Creatures{player, enemy}
+	enemy.AdjustEquipmentSlots()
+	*c = Creatures{player, companion, enemy}
 	obj, err := NewObject(24, 15, "heal.json")
 	*o = Objects{obj}
 	if err != nil {
 		fmt.Println(err)
 	}
+	for i, v := range []string{"helmet.json", "leatherArmor.json", "ring.json",
+		"potionStrength.json", "grenadeLauncher.json", "grenade.json",
+		"scrollTeleport.json", "scrollMapping.json", "scrollFireball.json",
+		"ammoBox.json", "wandFlames.json", "shieldGenerator.json", "chest.json",
+		"scrollIdentify.json", "repairKit.json"} {
+		armor, err := NewObject(2+i, 2, v)
+		if err != nil {
+			fmt.Println(err)
+		}
+		*o = append(*o, armor)
+	}
 	var c2 = Creatures{}
-	*b, c2, err = LoadJsonMap("smallInn.json")
+	var o2 = Objects{}
+	*b, c2, o2, err = LoadJsonMap("smallInn.json")
 	if err != nil {
 		fmt.Println(err)
 	}
 	*c = append(*c, c2...)
+	*o = append(*o, o2...)
 }
 
 func StartGame(b *Board, c *Creatures, o *Objects) {
@@ -133,6 +167,9 @@ func StartGame(b *Board, c *Creatures, o *Objects) {
 func init() {
 	rand.Seed(time.Now().UTC().UnixNano())
 	InitializeFOVTables()
+	InitializeCombatListeners()
+	InitializeEffects()
+	InitializeBehaviours()
 	InitializeBLT()
 	InitializeKeyboardLayouts()
 	ReadOptionsControls()
@@ -29,16 +29,19 @@ package main
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"unicode/utf8"
 )
 
 type Tile struct {
 	// Tiles are map cells - floors, walls, doors.
+	// Exits (like stairs) attract fleeing monsters.
+	// Dark tiles help Creatures to hide.
 	BasicProperties
 	VisibilityProperties
 	Explored bool
 	CollisionProperties
+	Exit bool
+	Dark bool
 }
 
 type MapJson struct {
@@ -55,8 +58,18 @@ type MapJson struct {
 	Explored       map[string]bool
 	Blocked        map[string]bool
 	BlocksSight    map[string]bool
+	Exit           map[string]bool
+	Dark           map[string]bool
 	MonstersCoords [][]int
 	MonstersTypes  []string
+	MonstersGroups []string
+	MonstersRoutes [][][]int
+	MonstersPosts  [][]int
+	MonstersAreas  [][]int
+	Containers     map[string]string
+	Depth          int
+	LootCoords     [][]int
+	LootTables     []string
 }
 
 /* Board is map representation, that uses 2d slice
@@ -85,7 +98,7 @@ func NewTile(layer, x, y int, character, name, color, colorDark string,
 	tileVisibilityProperties := VisibilityProperties{layer, alwaysVisible}
 	tileCollisionProperties := CollisionProperties{blocked, blocksSight}
 	tileNew := &Tile{tileBasicProperties, tileVisibilityProperties,
-		explored, tileCollisionProperties}
+		explored, tileCollisionProperties, false, false}
 	return tileNew, err
 }
 
@@ -127,17 +140,22 @@ func ReplaceTile(t *Tile, s string, m *MapJson) {
 	t.Explored = m.Explored[s]
 	t.Blocked = m.Blocked[s]
 	t.BlocksSight = m.BlocksSight[s]
+	t.Exit = m.Exit[s]
+	t.Dark = m.Dark[s]
 }
 
-func LoadJsonMap(mapFile string) (Board, Creatures, error) {
+func LoadJsonMap(mapFile string) (Board, Creatures, Objects, error) {
 	/* Function LoadJsonMap takes string (name of json map file) as argument,
-	   and returns Board (ie map), Creatures (included in premade json maps)
-	   and error.
+	   and returns Board (ie map), Creatures (included in premade json maps),
+	   Objects (containers placed on map) and error.
 	   It uses new type - struct MapJson - to store all values read from file.
 	   Panics if unmarshalling encounters any error.
 	   Other possible errors are about internal structure of json file:
 	       - length of Data and Layouts has to be the same
-	       - length of MonstersCoords and MonstersTypes has to be the same.
+	       - length of MonstersCoords and MonstersTypes has to be the same
+	       - MonstersGroups is optional, but if present, it has to be
+	         of the same length as well; the same applies to
+	         MonstersRoutes, MonstersPosts and MonstersAreas.
 	   It is important because instead of using multi-type json lists
 	   (it would be possible to store map monsters as [x: int, y: int, file: string])
 	   there are independent structures. The reason is Go's limitations: bot lists
@@ -152,8 +170,19 @@ func LoadJsonMap(mapFile string) (Board, Creatures, error) {
 	       - they are not created *randomly*
 	           = areas ("rooms") are specified in JsonMap.Data
 	           = they are filled using prefabs (JsonMap.Layouts)
-	   At the end, monsters are created and placed on map (their datas are stored
-	   in json map as MonstersCoords (x, y) and MonstersTypes (their json files). */
+	   Then monsters are created and placed on map (their datas are stored
+	   in json map as MonstersCoords (x, y) and MonstersTypes (their json files);
+	   MonstersGroups may assign them to groups - empty string means no group;
+	   MonstersRoutes, MonstersPosts and MonstersAreas set their patrol
+	   routes, guard posts, and idle areas - empty list means none;
+	   check patrol.go).
+	   At the end, containers are created on every tile which symbol is
+	   listed in Containers legend (symbol: json file of container); tile
+	   itself stays intact, so ie wardrobe is still impassable.
+	   Finally, loot tables listed in LootTables are rolled, and items
+	   are placed on the floor, on LootCoords. Depth of map is stored
+	   in CurrentDepth before creating monsters and containers, as it
+	   is used by loot tables. */
 	var jsonMap = &MapJson{}
 	var err error
 	err = MapFromJson(MapsPathJson+mapFile, jsonMap)
@@ -161,6 +190,7 @@ func LoadJsonMap(mapFile string) (Board, Creatures, error) {
 		fmt.Println(err)
 		panic(-1)
 	}
+	CurrentDepth = jsonMap.Depth
 	cells := jsonMap.Cells
 	data := jsonMap.Data
 	layouts := jsonMap.Layouts
@@ -170,18 +200,24 @@ func LoadJsonMap(mapFile string) (Board, Creatures, error) {
 		err = errors.New("Length of data and layouts does not match. " + txt)
 	}
 	thisMap := InitializeEmptyMap()
+	symbols := make([][]string, MapSizeX)
+	for x := range symbols {
+		symbols[x] = make([]string, MapSizeY)
+	}
 	for x := 0; x < len(cells[0]); x++ {
 		for y := 0; y < len(cells); y++ {
 			// y,x because - due to 2darray nature - there is height first, width later...
 			ReplaceTile(thisMap[x][y], string(cells[y][x]), jsonMap)
+			symbols[x][y] = string(cells[y][x])
 		}
 	}
 	for i, room := range data {
 		layoutsToChoose := layouts[i]
-		layout := layoutsToChoose[rand.Intn(len(layoutsToChoose))]
+		layout := layoutsToChoose[MapRand.Intn(len(layoutsToChoose))]
 		for x := 0; x < len(layout[0]); x++ {
 			for y := 0; y < len(layout); y++ {
 				ReplaceTile(thisMap[room[0]+x][room[1]+y], string(layout[y][x]), jsonMap)
+				symbols[room[0]+x][room[1]+y] = string(layout[y][x])
 			}
 		}
 	}
@@ -191,13 +227,66 @@ func LoadJsonMap(mapFile string) (Board, Creatures, error) {
 		txt := MapMonstersCoordsAiError(len(coords), len(aiTypes), mapFile)
 		err = errors.New("Length of MonstersCoords and MonstersTypes does not match. " + txt)
 	}
+	if len(jsonMap.MonstersGroups) > 0 && len(jsonMap.MonstersGroups) != len(coords) {
+		txt := MapMonstersDataError("MonstersGroups", len(coords), len(jsonMap.MonstersGroups), mapFile)
+		err = errors.New("Length of MonstersCoords and MonstersGroups does not match. " + txt)
+	}
+	if len(jsonMap.MonstersRoutes) > 0 && len(jsonMap.MonstersRoutes) != len(coords) {
+		txt := MapMonstersDataError("MonstersRoutes", len(coords), len(jsonMap.MonstersRoutes), mapFile)
+		err = errors.New("Length of MonstersCoords and MonstersRoutes does not match. " + txt)
+	}
+	if len(jsonMap.MonstersPosts) > 0 && len(jsonMap.MonstersPosts) != len(coords) {
+		txt := MapMonstersDataError("MonstersPosts", len(coords), len(jsonMap.MonstersPosts), mapFile)
+		err = errors.New("Length of MonstersCoords and MonstersPosts does not match. " + txt)
+	}
+	if len(jsonMap.MonstersAreas) > 0 && len(jsonMap.MonstersAreas) != len(coords) {
+		txt := MapMonstersDataError("MonstersAreas", len(coords), len(jsonMap.MonstersAreas), mapFile)
+		err = errors.New("Length of MonstersCoords and MonstersAreas does not match. " + txt)
+	}
 	var creatures = Creatures{}
 	for j := 0; j < len(coords); j++ {
 		monster, err := NewCreature(coords[j][0], coords[j][1], aiTypes[j]+".json")
 		if err != nil {
 			fmt.Println(err)
 		}
+		if j < len(jsonMap.MonstersGroups) && jsonMap.MonstersGroups[j] != "" {
+			monster.Group = jsonMap.MonstersGroups[j]
+		}
+		var route [][]int
+		var post, area []int
+		if j < len(jsonMap.MonstersRoutes) {
+			route = jsonMap.MonstersRoutes[j]
+		}
+		if j < len(jsonMap.MonstersPosts) {
+			post = jsonMap.MonstersPosts[j]
+		}
+		if j < len(jsonMap.MonstersAreas) {
+			area = jsonMap.MonstersAreas[j]
+		}
+		if errPatrol := monster.SetPatrol(route, post, area); errPatrol != nil {
+			fmt.Println(errPatrol)
+		}
 		creatures = append(creatures, monster)
 	}
-	return thisMap, creatures, err
+	objects, err2 := PlaceContainers(jsonMap, symbols)
+	if err2 != nil {
+		fmt.Println(err2)
+	}
+	lootCoords := jsonMap.LootCoords
+	lootTables := jsonMap.LootTables
+	if len(lootCoords) != len(lootTables) {
+		txt := MapLootCoordsTablesError(len(lootCoords), len(lootTables), mapFile)
+		err = errors.New("Length of LootCoords and LootTables does not match. " + txt)
+	}
+	for k := 0; k < len(lootCoords) && k < len(lootTables); k++ {
+		x, y := lootCoords[k][0], lootCoords[k][1]
+		loot, err3 := RollLoot(lootTables[k], CurrentDepth, x, y)
+		if err3 != nil {
+			fmt.Println(err3)
+		}
+		for _, v := range loot {
+			PlaceObject(v, x, y, &objects)
+		}
+	}
+	return thisMap, creatures, objects, err
 }
@@ -44,7 +44,16 @@ type Creature struct {
 	VisibilityProperties
 	CollisionProperties
 	FighterProperties
+	ExperienceProperties
+	LootProperties
+	TradeProperties
+	MoraleProperties
+	AwarenessProperties
+	MemoryProperties
+	CompanionProperties
+	PatrolProperties
 	EquipmentComponent
+	StatusComponent
 }
 
 // Creatures holds every creature on map.
@@ -92,15 +101,112 @@ func NewCreature(x, y int, monsterFile string) (*Creature, error) {
 		txt := InitialDefenseError(monster.Defense)
 		err2 = errors.New("Creature defense value is smaller than 0." + txt)
 	}
+	if errStatus := ValidateStatuses(monster.HitStatuses); errStatus != nil {
+		err2 = errStatus
+	}
+	if errBehaviour := ValidateBehaviours(monster.Behaviours); errBehaviour != nil {
+		err2 = errBehaviour
+	}
+	if _, ok := Relations[monster.FactionName()]; ok == false {
+		txt := FactionError(monster.FactionName())
+		err2 = errors.New("Creature belongs to unknown faction." + txt)
+	}
+	if monster.Speed <= 0 {
+		monster.Speed = SpeedNormal
+	}
+	if monster.Courage <= 0 {
+		monster.Courage = MoraleDefault
+	}
 	if monster.Equipment == nil {
 		monster.Equipment = Objects{}
 	}
-	if monster.Inventory == nil {
-		monster.Inventory = Objects{}
+	monster.CompactInventory()
+	monster.AdjustEquipmentSlots()
+	if errStock := monster.FillStock(); errStock != nil {
+		err2 = errStock
+	}
+	if monster.Gold < 0 {
+		txt := GoldError(monster.Gold)
+		err2 = errors.New("Creature has negative amount of gold." + txt)
+	}
+	if monster.LootTable != "" {
+		loot, errLoot := RollLoot(monster.LootTable, CurrentDepth, x, y)
+		if errLoot != nil {
+			err2 = errLoot
+		}
+		for _, v := range loot {
+			monster.AddToInventory(v)
+		}
 	}
 	return monster, err2
 }
 
+func (c *Creature) AdjustEquipmentSlots() {
+	/* Method AdjustEquipmentSlots makes sure that Equipment of receiver
+	   has exactly one place for every slot declared in objects.go.
+	   Json files (and older saves) may provide shorter lists - missing
+	   slots are filled with nils, ie are empty. */
+	for len(c.Equipment) < SlotMax {
+		c.Equipment = append(c.Equipment, nil)
+	}
+}
+
+func (c *Creature) EffectiveAttack() int {
+	/* Method EffectiveAttack returns attack value of receiver:
+	   base Attack with AttackModifier of every equipped item
+	   (broken items give smaller bonuses), and strength Status.
+	   It never returns negative value. */
+	attack := c.Attack + c.StatusPower(StatusStrength)
+	for _, v := range c.Equipment {
+		if v != nil {
+			attack += v.Modifier(v.AttackModifier)
+		}
+	}
+	if attack < 0 {
+		attack = 0
+	}
+	return attack
+}
+
+func (c *Creature) EffectiveDefense() int {
+	/* Method EffectiveDefense works as EffectiveAttack, but for
+	   Defense, DefenseModifier and protection Status. */
+	defense := c.Defense + c.StatusPower(StatusProtection)
+	for _, v := range c.Equipment {
+		if v != nil {
+			defense += v.Modifier(v.DefenseModifier)
+		}
+	}
+	if defense < 0 {
+		defense = 0
+	}
+	return defense
+}
+
+func (c *Creature) EffectiveHPMax() int {
+	/* Method EffectiveHPMax works as EffectiveAttack, but for
+	   HPMax and HPMaxModifier. Max HP is always at least 1. */
+	hp := c.HPMax
+	for _, v := range c.Equipment {
+		if v != nil {
+			hp += v.Modifier(v.HPMaxModifier)
+		}
+	}
+	if hp < 1 {
+		hp = 1
+	}
+	return hp
+}
+
+func (c *Creature) ClampHP() {
+	/* Method ClampHP is called after changes in equipment; it makes
+	   sure that HPCurrent will not exceed effective max HP, for example
+	   after removing ring of vitality. */
+	if hpMax := c.EffectiveHPMax(); c.HPCurrent > hpMax {
+		c.HPCurrent = hpMax
+	}
+}
+
 func (c *Creature) MoveOrAttack(tx, ty int, b Board, o *Objects, all Creatures) bool {
 	/* Method MoveOrAttack decides if Creature will move or attack other Creature;
 	   It has *Creature receiver, and takes tx, ty (coords) integers as arguments,
@@ -108,6 +214,12 @@ func (c *Creature) MoveOrAttack(tx, ty int, b Board, o *Objects, all Creatures)
 	   Starts by target that is nil, then iterates through Creatures. If there is
 	   Creature on targeted tile, that Creature becomes new target for attack.
 	   Otherwise, Creature moves to specified Tile.
+	   Player does not attack merchants - bumping into them
+	   opens trade menu instead, unless they are hostile. Player swaps
+	   places with companions instead of attacking them. Moving makes
+	   noise (sneaking is slower, but quieter - see stealth.go).
+	   After moving, player picks up items that match auto-pickup
+	   rules.
 	   It's supposed to take player as receiver (attack / moving enemies is
 	   handled differently - check ai.go and combat.go). */
 	var target *Creature
@@ -120,11 +232,25 @@ func (c *Creature) MoveOrAttack(tx, ty int, b Board, o *Objects, all Creatures)
 			}
 		}
 	}
-	if target != nil {
-		c.AttackTarget(target, o)
+	if target != nil && target.AIType == MerchantAI && c.AIType == PlayerAI &&
+		target.IsHostile(c) == false {
+		turnSpent = c.Trade(target)
+	} else if target != nil && target.AIType == CompanionAI && c.AIType == PlayerAI {
+		turnSpent = c.SwapPlaces(target)
+	} else if target != nil {
+		c.AttackTarget(target, b, o, all)
 		turnSpent = true
 	} else {
 		turnSpent = c.Move(tx, ty, b)
+		if turnSpent == true && c.Sneaking == true {
+			c.SpendEnergy(ActionCostSneak - ActionCostMove)
+			EmitNoise(c.X, c.Y, NoiseSneak, c)
+		} else if turnSpent == true {
+			EmitNoise(c.X, c.Y, NoiseMove, c)
+		}
+		if turnSpent == true && c.AIType == PlayerAI {
+			c.AutoPickUp(o)
+		}
 	}
 	return turnSpent
 }
@@ -142,6 +268,7 @@ func (c *Creature) Move(tx, ty int, b Board) bool {
 		if b[newX][newY].Blocked == false {
 			c.X = newX
 			c.Y = newY
+			c.SpendEnergy(ActionCostMove)
 			turnSpent = true
 		}
 	}
@@ -153,24 +280,41 @@ func (c *Creature) PickUp(o *Objects) bool {
 	   and slice of *Object as argument.
 	   Creature tries to pick object up.
 	   If creature stands on object that is possible to pick,
-	   object is added to c's inventory, and removed
+	   object is added to c's inventory (stackable items are
+	   merged with stacks that are already there), and removed
 	   from "global" slice of objects.
+	   If there is more than one item on the tile, player
+	   chooses items to pick up from the menu (check pickup.go),
+	   and how many items to take from every stack.
 	   Picking objects up takes turn only if it is
-	   successful attempt. */
+	   successful attempt; it fails if there is no free
+	   slot in Inventory. Every picked item costs time. */
 	turnSpent := false
-	obj := *o
-	for i := 0; i < len(obj); i++ {
-		if obj[i].X == c.X && obj[i].Y == c.Y && obj[i].Pickable == true {
-			if c.AIType == PlayerAI {
-				AddMessage("You found " + obj[i].Name + ".")
+	items := ItemsOnTile(c.X, c.Y, *o)
+	if len(items) == 0 {
+		if c.AIType == PlayerAI {
+			AddMessage("There is nothing to pick up here.")
+		}
+		return turnSpent
+	}
+	if len(items) > 1 && c.AIType == PlayerAI {
+		items = c.PickUpMenu(items)
+	} else {
+		items = items[:1]
+	}
+	for _, v := range items {
+		n := v.Quantity
+		if c.AIType == PlayerAI && v.Quantity > 1 {
+			n = AskQuantity("Pick up how many "+DisplayName(v)+"?", v.Quantity)
+			if n <= 0 {
+				continue
 			}
-			c.Inventory = append(c.Inventory, obj[i])
-			copy(obj[i:], obj[i+1:])
-			obj[len(obj)-1] = nil
-			*o = obj[:len(obj)-1]
-			turnSpent = true
+		}
+		if c.PickUpQuantity(o, v, n) == false {
 			break
 		}
+		c.SpendEnergy(ActionCostPickUp)
+		turnSpent = true
 	}
 	return turnSpent
 }
@@ -187,21 +331,33 @@ func (c *Creature) DropFromInventory(objects *Objects, index int) bool {
 	   to do any actions on these objects.
 	   Drop do two things:
 	   at first, it adds specific item to the game map,
-	   then it removes this item from its owner Inventory. */
+	   then it removes this item from its owner Inventory.
+	   Player chooses how many items from stack to drop; stack
+	   is split, and dropped part is merged with stacks
+	   of the same kind that lie on the floor. */
 	turnSpent := false
-	objs := *objects
+	item := c.Inventory[index]
+	n := item.Quantity
+	if c.AIType == PlayerAI && item.Quantity > 1 {
+		n = AskQuantity("Drop how many?", item.Quantity)
+		if n <= 0 {
+			return turnSpent
+		}
+	}
+	var object *Object
+	if n < item.Quantity {
+		// Split part of stack...
+		object = item.SplitStack(n)
+	} else {
+		// ...or remove item (whole stack) from inventory...
+		object = c.RemoveFromInventory(index)
+	}
 	if c.AIType == PlayerAI {
-		AddMessage("You dropped " + c.Inventory[index].Name + ".")
-	}
-	// Add item to the map.
-	object := c.Inventory[index]
-	object.X, object.Y = c.X, c.Y
-	objs = append(objs, object)
-	*objects = objs
-	// Then remove item from inventory.
-	copy(c.Inventory[index:], c.Inventory[index+1:])
-	c.Inventory[len(c.Inventory)-1] = nil
-	c.Inventory = c.Inventory[:len(c.Inventory)-1]
+		AddMessage("You dropped " + FormatObjectName(object) + ".")
+	}
+	// ...then add it to the map.
+	PlaceObject(object, c.X, c.Y, objects)
+	c.SpendEnergy(ActionCostDrop)
 	turnSpent = true
 	return turnSpent
 }
@@ -219,21 +375,20 @@ func (c *Creature) DropFromEquipment(objects *Objects, slot int) bool {
 	   slice, Equipment is supposed to be "fixed size" - slots are present
 	   all the time, but the can be empty (ie nil) or occupied (ie object). */
 	turnSpent := false
-	objs := *objects
 	object := c.Equipment[slot]
 	if object == nil {
 		return turnSpent // turn is not spent because there is no object to drop
 	}
 	// else {
 	if c.AIType == PlayerAI {
-		AddMessage("You removed and dropped " + object.Name + ".")
+		AddMessage("You removed and dropped " + DisplayName(object) + ".")
 	}
 	// add item to map
-	object.X, object.Y = c.X, c.Y
-	objs = append(objs, object)
-	*objects = objs
+	PlaceObject(object, c.X, c.Y, objects)
 	// then remove from slot
 	c.Equipment[slot] = nil
+	c.ClampHP()
+	c.SpendEnergy(ActionCostDrop)
 	turnSpent = true
 	return turnSpent
 }
@@ -255,7 +410,7 @@ func (c *Creature) EquipItem(o *Object, slot int) (bool, error) {
 		txt := EquipSlotNotNilError(c, slot)
 		err = errors.New("Creature tried to equip item into already occupied slot." + txt)
 	}
-	if o.Slot != slot {
+	if SlotsMatch(o.Slot, slot) == false {
 		txt := EquipWrongSlotError(o.Slot, slot)
 		err = errors.New("Creature tried to equip item into wrong slot." + txt)
 	}
@@ -267,12 +422,11 @@ func (c *Creature) EquipItem(o *Object, slot int) (bool, error) {
 	if err != nil {
 		fmt.Println(err)
 	}
-	copy(c.Inventory[index:], c.Inventory[index+1:])
-	c.Inventory[len(c.Inventory)-1] = nil
-	c.Inventory = c.Inventory[:len(c.Inventory)-1]
+	c.RemoveFromInventory(index)
 	if c.AIType == PlayerAI {
-		AddMessage("You equipped " + o.Name + ".")
+		AddMessage("You equipped " + DisplayName(o) + ".")
 	}
+	c.SpendEnergy(ActionCostEquip)
 	turnSpent = true
 	return turnSpent, err
 }
@@ -280,18 +434,27 @@ func (c *Creature) EquipItem(o *Object, slot int) (bool, error) {
 func (c *Creature) DequipItem(slot int) (bool, error) {
 	/* DequipItem is method of Creature. It is called when receiver is about
 	   to dequip weapon from "ready" equipment slot.
-	   At first, weapon is added to Inventory, then Equipment slot is set to nil. */
+	   At first, weapon is added to Inventory, then Equipment slot is set to nil.
+	   Item can not be dequipped if there is no free slot in Inventory. */
 	var err error
+	turnSpent := false
 	if c.Equipment[slot] == nil {
 		txt := DequipNilError(c, slot)
 		err = errors.New("Creature tried to DequipItem that was nil." + txt)
+		return turnSpent, err
+	}
+	if c.AddToInventory(c.Equipment[slot]) == false {
+		if c.AIType == PlayerAI {
+			AddMessage("Your inventory is full.")
+		}
+		return turnSpent, err
 	}
 	if c.AIType == PlayerAI {
-		AddMessage("You dequipped " + c.Equipment[slot].Name + ".")
+		AddMessage("You dequipped " + DisplayName(c.Equipment[slot]) + ".")
 	}
-	turnSpent := false
-	c.Inventory = append(c.Inventory, c.Equipment[slot]) //adding items to inventory should have own function, that will check "bounds" of inventory
 	c.Equipment[slot] = nil
+	c.ClampHP()
+	c.SpendEnergy(ActionCostEquip)
 	turnSpent = true
 	return turnSpent, err
 }
@@ -299,7 +462,9 @@ func (c *Creature) DequipItem(slot int) (bool, error) {
 func (c *Creature) Die(o *Objects) {
 	/* Method Die is called when Creature's HP drops below zero.
 	   Die() has *Creature as receiver.
-	   Receiver properties changes to fit better to corpse. */
+	   Receiver properties changes to fit better to corpse.
+	   Everything that Creature carried is put into corpse container
+	   (see NewCorpse in containers.go), that may be looted later. */
 	c.Layer = DeadLayer
 	c.Name = "corpse of " + c.Name
 	c.Color = "dark red"
@@ -308,8 +473,9 @@ func (c *Creature) Die(o *Objects) {
 	c.Blocked = false
 	c.BlocksSight = false
 	c.AIType = NoAI
-	for i, _ := range SlotStrings {
-		c.DropFromEquipment(o, i)
+	c.Statuses = nil
+	if corpse := NewCorpse(c); corpse != nil {
+		*o = append(*o, corpse)
 	}
 	ZeroLastTarget(c)
 }
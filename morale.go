@@ -0,0 +1,223 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"strings"
+)
+
+const (
+	// Default Courage of Creatures that do not declare it.
+	MoraleDefault = 60
+	// Fear added for every hostile Creature that outnumbers
+	// receiver and its allies.
+	MoraleOutnumbered = 20
+	// Fleeing Creature rallies when its fear drops that much
+	// below its Courage.
+	MoraleRallyMargin = 20
+	// HP recovered every turn by fleeing Creature that does
+	// not see any enemy.
+	MoraleRecovery = 1
+)
+
+const (
+	// Values used for building safety maps. Distances are multiplied
+	// by SafetyStep, and distance from threats is multiplied by
+	// (negative) SafetyCoefficient - bigger than SafetyStep - so
+	// fleeing Creatures prefer running around enemies instead of
+	// getting stuck in dead ends. Exits lower the value even more.
+	SafetyStep        = 10
+	SafetyCoefficient = 12
+	SafetyExitBonus   = 100
+)
+
+func (c *Creature) Fear(b Board, cs Creatures) int {
+	/* Method Fear returns fear of receiver: percent of HP it lost,
+	   increased when it is outnumbered by visible enemies. */
+	fear := 100 - c.HPCurrent*100/c.EffectiveHPMax()
+	hostiles, allies := 0, 0
+	for _, v := range cs {
+		if v == c || v.HPCurrent <= 0 || IsInFOV(b, c.X, c.Y, v.X, v.Y) == false {
+			continue
+		}
+		switch c.Relation(v) {
+		case RelationHostile:
+			hostiles++
+		case RelationAllied:
+			allies++
+		}
+	}
+	if outnumbered := hostiles - allies - 1; outnumbered > 0 {
+		fear += outnumbered * MoraleOutnumbered
+	}
+	return fear
+}
+
+func (c *Creature) UpdateMorale(b Board, cs Creatures) {
+	/* Method UpdateMorale is called before every turn of monster.
+	   Creature starts fleeing if its fear is bigger than its Courage.
+	   Fleeing Creature that does not see any enemies catches its
+	   breath (recovers some HP), and rallies once its fear
+	   is low enough. Fearless Creatures never flee. */
+	if c.Fearless == true || c.AIType == PlayerAI {
+		return
+	}
+	visible := IsInFOV(b, cs[0].X, cs[0].Y, c.X, c.Y)
+	if c.Fleeing == false {
+		if c.Fear(b, cs) > c.Courage {
+			c.Fleeing = true
+			if visible == true {
+				AddMessage(strings.ToUpper(c.Name[:1]) + c.Name[1:] + " flees!")
+			}
+		}
+		return
+	}
+	if VisibleHostile(c, b, cs) == nil && c.HPCurrent < c.EffectiveHPMax() {
+		c.HPCurrent += MoraleRecovery
+		c.ClampHP()
+	}
+	if c.Fear(b, cs) <= c.Courage-MoraleRallyMargin {
+		c.Fleeing = false
+		if visible == true {
+			AddMessage(strings.ToUpper(c.Name[:1]) + c.Name[1:] + " rallies!")
+		}
+	}
+}
+
+func DistanceMap(b Board, sources [][]int) [][]*Node {
+	/* Function DistanceMap uses pathfinding grid (see pathfinding.go)
+	   to compute distance (in steps) from the nearest source to every
+	   tile that is reachable. Unreachable tiles keep nodeInitialWeight.
+	   Creatures are ignored, as they move anyway. */
+	nodes := TilesToNodes()
+	var frontiers = []*Node{}
+	for _, v := range sources {
+		nodes[v[0]][v[1]].Weight = 0
+		frontiers = append(frontiers, nodes[v[0]][v[1]])
+	}
+	for w := 1; len(frontiers) > 0; w++ {
+		var adjacent = []*Node{}
+		for _, f := range frontiers {
+			for x := f.X - 1; x <= f.X+1; x++ {
+				for y := f.Y - 1; y <= f.Y+1; y++ {
+					if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+						continue
+					}
+					if nodes[x][y].Weight != nodeInitialWeight || b[x][y].Blocked == true {
+						continue
+					}
+					nodes[x][y].Weight = w
+					adjacent = append(adjacent, nodes[x][y])
+				}
+			}
+		}
+		frontiers = adjacent
+	}
+	return nodes
+}
+
+func SafetyMap(b Board, threats Creatures) [][]int {
+	/* Function SafetyMap creates "safety map" - inverted distance map
+	   of threats. Tiles far from threats, and exits, have smaller
+	   values. Then, values are smoothed (every tile is at most
+	   SafetyStep bigger than its smallest neighbour), so Creature
+	   that always moves to neighbour with the smallest value finds
+	   the way to the safest place - even if it has to pass
+	   near its enemies. */
+	var sources = [][]int{}
+	for _, v := range threats {
+		sources = append(sources, []int{v.X, v.Y})
+	}
+	distances := DistanceMap(b, sources)
+	safety := make([][]int, MapSizeX)
+	for x := range safety {
+		safety[x] = make([]int, MapSizeY)
+		for y := range safety[x] {
+			d := distances[x][y].Weight
+			if d == nodeInitialWeight {
+				safety[x][y] = 0
+				continue
+			}
+			safety[x][y] = -d * SafetyCoefficient
+			if b[x][y].Exit == true {
+				safety[x][y] -= SafetyExitBonus
+			}
+		}
+	}
+	for changed := true; changed == true; {
+		changed = false
+		for x := 0; x < MapSizeX; x++ {
+			for y := 0; y < MapSizeY; y++ {
+				if b[x][y].Blocked == true {
+					continue
+				}
+				for nx := x - 1; nx <= x+1; nx++ {
+					for ny := y - 1; ny <= y+1; ny++ {
+						if nx < 0 || nx >= MapSizeX || ny < 0 || ny >= MapSizeY {
+							continue
+						}
+						if b[nx][ny].Blocked == true {
+							continue
+						}
+						if safety[nx][ny]+SafetyStep < safety[x][y] {
+							safety[x][y] = safety[nx][ny] + SafetyStep
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return safety
+}
+
+func (c *Creature) FleeFrom(b Board, cs Creatures, threats Creatures) bool {
+	/* Method FleeFrom moves receiver to adjacent, free tile that has
+	   the smallest value on safety map. It fails if receiver is
+	   cornered, ie there is no tile safer than the current one. */
+	safety := SafetyMap(b, threats)
+	bestX, bestY := 0, 0
+	best := safety[c.X][c.Y]
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			x, y := c.X+dx, c.Y+dy
+			if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+				continue
+			}
+			if b[x][y].Blocked == true || GetAliveCreatureFromTile(x, y, cs) != nil {
+				continue
+			}
+			if safety[x][y] < best {
+				best, bestX, bestY = safety[x][y], dx, dy
+			}
+		}
+	}
+	if bestX == 0 && bestY == 0 {
+		return false
+	}
+	return c.Move(bestX, bestY, b)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+const (
+	// Volume of noises made by different actions.
+	NoiseMove      = 4
+	NoiseAttack    = 8
+	NoiseShot      = 12
+	NoiseExplosion = 16
+)
+
+const (
+	// Doors, and other tiles that block sight, but do not block
+	// movement, muffle sounds - passing them costs more volume.
+	NoiseStepCost = 1
+	NoiseDoorCost = 4
+	// Number of turns that Creature spends searching around
+	// the last known position of its target.
+	SearchDuration = 5
+)
+
+// Noise is single sound that propagates through map.
+type Noise struct {
+	/* X, Y are coords of origin of sound, and Volume
+	   is maximum distance that it can travel. Source is
+	   Creature that made noise; it may be nil (then,
+	   everyone is interested in sound). */
+	X, Y   int
+	Volume int
+	Source *Creature
+}
+
+// NoiseQueue holds all noises made since last propagation.
+var NoiseQueue = []Noise{}
+
+func EmitNoise(x, y, volume int, source *Creature) {
+	/* Function EmitNoise adds noise to NoiseQueue. Noises are
+	   not heard immediately - they are propagated during
+	   monsters turns (see CreaturesTakeTurn). */
+	NoiseQueue = append(NoiseQueue, Noise{x, y, volume, source})
+}
+
+func NoiseLevels(b Board, n Noise) [][]int {
+	/* Function NoiseLevels returns 2d slice, indexed as Board, with
+	   volume of noise n on every tile (0 means silence). Sound spreads
+	   like water - around walls, not through them; tiles that block
+	   sight (like doors) muffle it. */
+	levels := make([][]int, MapSizeX)
+	for x := range levels {
+		levels[x] = make([]int, MapSizeY)
+	}
+	if n.Volume <= 0 {
+		return levels
+	}
+	levels[n.X][n.Y] = n.Volume
+	var frontiers = [][]int{[]int{n.X, n.Y}}
+	for len(frontiers) > 0 {
+		var adjacent = [][]int{}
+		for _, f := range frontiers {
+			for x := f[0] - 1; x <= f[0]+1; x++ {
+				for y := f[1] - 1; y <= f[1]+1; y++ {
+					if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+						continue
+					}
+					if b[x][y].Blocked == true {
+						continue
+					}
+					cost := NoiseStepCost
+					if b[x][y].BlocksSight == true {
+						cost = NoiseDoorCost
+					}
+					level := levels[f[0]][f[1]] - cost
+					if level > levels[x][y] {
+						levels[x][y] = level
+						adjacent = append(adjacent, []int{x, y})
+					}
+				}
+			}
+		}
+		frontiers = adjacent
+	}
+	return levels
+}
+
+func PropagateNoises(b Board, cs Creatures) {
+	/* Function PropagateNoises empties NoiseQueue. Every Creature
+	   that hears noise, made by its enemy (or by unknown source),
+	   and does not see any target, goes to investigate it.
+	   Sleeping Creatures may wake up - the louder noise, the
+	   bigger chance. */
+	for _, n := range NoiseQueue {
+		levels := NoiseLevels(b, n)
+		for _, c := range cs {
+			if c.AIType == PlayerAI || c.AIType == NoAI || c.HPCurrent <= 0 {
+				continue
+			}
+			if c == n.Source || levels[c.X][c.Y] <= 0 {
+				continue
+			}
+			if n.Source != nil && c.IsHostile(n.Source) == false {
+				continue
+			}
+			c.HearNoise(n, levels[c.X][c.Y], b, cs)
+		}
+	}
+	NoiseQueue = []Noise{}
+}
+
+func (c *Creature) HearNoise(n Noise, level int, b Board, cs Creatures) {
+	/* Method HearNoise makes receiver investigate origin of noise,
+	   unless it is busy with target that it sees. Level is volume
+	   of noise at position of receiver; it may wake sleeping
+	   receiver. Unaware Creatures become suspicious. */
+	if c.WakeUp(level) == false {
+		return
+	}
+	if c.Awareness == AwarenessAlert && VisibleHostile(c, b, cs) != nil {
+		return
+	}
+	if c.Awareness == AwarenessUnaware {
+		c.Awareness = AwarenessSuspicious
+	}
+	c.Remember(n.X, n.Y)
+}
+
+func (c *Creature) Remember(x, y int) {
+	/* Method Remember stores x, y as position that receiver
+	   should investigate. */
+	c.LastKnownX, c.LastKnownY = x, y
+	c.Investigating = true
+	c.SearchTurns = SearchDuration
+}
+
+func (c *Creature) UpdateMemory(b Board, cs Creatures) {
+	/* Method UpdateMemory is called before every turn of monster.
+	   Alert Creature remembers position of target that it sees,
+	   so it can search there once it loses sight, and shares it with
+	   its group. */
+	if c.Awareness != AwarenessAlert {
+		return
+	}
+	if t := VisibleHostile(c, b, cs); t != nil {
+		c.Remember(t.X, t.Y)
+		c.ShareTarget(t, b, cs)
+	}
+}
+
+func BehaviourInvestigateHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourInvestigateHandler moves receiver to the last
+	   known position of its target (or to origin of noise), then
+	   searches around for a few turns. If nothing is found, Creature
+	   gives up - it forgets position, and becomes unaware again.
+	   Suspicious Creatures investigate even if they see their target -
+	   they are not sure what they saw. */
+	if c.Investigating == false ||
+		(c.Awareness == AwarenessAlert && AITarget(c, b, cs) != nil) {
+		return false
+	}
+	if c.X != c.LastKnownX || c.Y != c.LastKnownY {
+		before := c.Energy
+		c.MoveTowards(b, cs, c.LastKnownX, c.LastKnownY, MovementStyle(n))
+		if c.Energy != before {
+			return true
+		}
+		// Path is blocked; search from here.
+	}
+	c.SearchTurns--
+	if c.SearchTurns <= 0 {
+		c.Investigating = false
+		c.Awareness = AwarenessUnaware
+		return true
+	}
+	c.Move(RandRange(-1, 1), RandRange(-1, 1), b)
+	return true
+}
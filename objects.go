@@ -41,6 +41,13 @@ const (
 	SlotWeaponPrimary
 	SlotWeaponSecondary
 	SlotWeaponMelee
+	SlotHead
+	SlotBody
+	SlotHands
+	SlotFeet
+	SlotShield
+	SlotRingLeft
+	SlotRingRight
 
 	SlotMax
 )
@@ -49,15 +56,15 @@ var SlotStrings = map[int]string{
 	SlotWeaponPrimary:   "weapon1",
 	SlotWeaponSecondary: "weapon2",
 	SlotWeaponMelee:     "weapon3",
+	SlotHead:            "head",
+	SlotBody:            "body",
+	SlotHands:           "hands",
+	SlotFeet:            "feet",
+	SlotShield:          "shield",
+	SlotRingLeft:        "ring1",
+	SlotRingRight:       "ring2",
 }
 
-const (
-	// Use cases, mostly for consumables.
-	UseNA = iota
-
-	UseHeal
-)
-
 const (
 	// Values for handling inventory actions.
 	ItemPass   = "pass"
@@ -65,6 +72,8 @@ const (
 	ItemEquip  = "equip"
 	ItemDequip = "dequip"
 	ItemUse    = "use"
+	ItemThrow  = "throw"
+	ItemRepair = "repair"
 )
 
 type Object struct {
@@ -75,6 +84,18 @@ type Object struct {
 	VisibilityProperties
 	CollisionProperties
 	ObjectProperties
+	ModifierProperties
+	AreaProperties
+	ThrowingProperties
+	StackProperties
+	AmmoProperties
+	RangeProperties
+	ChargeProperties
+	ContainerProperties
+	IdentityProperties
+	DurabilityProperties
+	PriceProperties
+	StealthProperties
 }
 
 // Objects holds every object on map.
@@ -93,6 +114,9 @@ func NewObject(x, y int, objectPath string) (*Object, error) {
 		panic(-1)
 	}
 	object.X, object.Y = x, y
+	if object.Quantity == 0 {
+		object.Quantity = 1
+	}
 	var err2 error
 	if object.Layer < 0 {
 		txt := LayerError(object.Layer)
@@ -110,7 +134,7 @@ func NewObject(x, y int, objectPath string) (*Object, error) {
 		txt := CharacterLengthError(object.Char)
 		err = errors.New("Object character string length is not equal to 1." + txt)
 	}
-	if object.Consumable == true && object.Use == UseNA {
+	if object.Consumable == true && len(object.Effects) == 0 {
 		txt := ConsumableWithoutUseError()
 		err = errors.New("Object is consumable, but has undefined use case." + txt)
 	}
@@ -119,9 +143,43 @@ func NewObject(x, y int, objectPath string) (*Object, error) {
 		txt := EquippableSlotError(object.Equippable, object.Slot)
 		err = errors.New("'equippable' and 'slot' values does not match." + txt)
 	}
-	if object.Equippable == true && object.Consumable == true {
-		//TODO: temporary
-		err = errors.New("For now, <equippable> and <consumable> should not exists at the same time.")
+	if errStatus := ValidateStatuses(object.HitStatuses); errStatus != nil {
+		err2 = errStatus
+	}
+	if errEffect := ValidateEffects(object.Effects); errEffect != nil {
+		err2 = errEffect
+	}
+	if object.Quantity < 1 || (object.Stackable == false && object.Quantity > 1) ||
+		(object.Stackable == true && object.Equippable == true) {
+		txt := StackError(object.Stackable, object.Equippable, object.Quantity)
+		err2 = errors.New("Object has invalid stack properties." + txt)
+	}
+	if object.Durability < 0 || object.Durability > object.DurabilityMax {
+		txt := DurabilityError(object.Durability, object.DurabilityMax)
+		err2 = errors.New("Object has invalid durability." + txt)
+	}
+	if object.Range < 0 {
+		txt := RangeError(object.Range)
+		err2 = errors.New("Object has negative range." + txt)
+	}
+	if object.Price < 0 || object.SellPrice < 0 {
+		txt := PriceError(object.Price, object.SellPrice)
+		err2 = errors.New("Object has negative price." + txt)
+	}
+	if object.Container == true && object.Pickable == true {
+		txt := ContainerPickableError()
+		err2 = errors.New("Containers can not be pickable." + txt)
+	}
+	if object.Container == true {
+		if errContents := object.FillContainer(); errContents != nil {
+			err2 = errContents
+		}
+	}
+	if object.Charges < 0 || object.Charges > object.ChargesMax ||
+		(object.ChargesMax > 0 && object.Stackable == true) ||
+		(object.RechargeTime > 0 && object.ChargesMax == 0) {
+		txt := ChargesError(object.Charges, object.ChargesMax, object.RechargeTime)
+		err2 = errors.New("Object has invalid charges." + txt)
 	}
 	return object, err2
 }
@@ -136,10 +194,11 @@ func GatherItemOptions(o *Object) ([]string, error) {
 	if o.Equippable == true {
 		options = append(options, ItemEquip)
 	}
-	if o.Use != UseNA {
+	if len(o.Effects) > 0 {
 		options = append(options, ItemUse)
 	}
 	if o.Pickable == true {
+		options = append(options, ItemThrow)
 		options = append(options, ItemDrop)
 	}
 	if len(options) == 0 {
@@ -159,7 +218,10 @@ func GatherEquipmentOptions(o *Object) ([]string, error) {
 	if o.Equippable == true {
 		options = append(options, ItemDequip)
 	}
-	if o.Use != UseNA {
+	if o.IsDamaged() == true {
+		options = append(options, ItemRepair)
+	}
+	if len(o.Effects) > 0 {
 		options = append(options, ItemUse)
 	}
 	if o.Pickable == true {
@@ -182,58 +244,96 @@ func GetEquippablesFromInventory(c *Creature, slot int) Objects {
 	var eq = Objects{}
 	for i := 0; i < len(c.Inventory); i++ {
 		item := c.Inventory[i]
-		if item != nil && item.Equippable == true && item.Slot == slot {
+		if item != nil && item.Equippable == true && SlotsMatch(item.Slot, slot) == true {
 			eq = append(eq, item)
 		}
 	}
 	return eq
 }
 
-func (o *Object) UseItem(c *Creature) (bool, error) {
-	/* Method UseItem has Object as receiver and takes Creature as argument.
-	   It uses Use value of receiver to determine what action will be performed.
-	   If there is no valid o.Use, it breaks switch statement (need proper
-	   error handling).
-	   It tries to remove item from inventory by calling DestroyItem function,
-	   but item will be removed only if its Consumable is set to true.
-	   Returns turnSpent that is true, unless o.Use is invalid. */
+func SlotsMatch(itemSlot, slot int) bool {
+	/* Function SlotsMatch takes two slot indicators - slot declared
+	   by item, and slot of Equipment - and returns true if item
+	   can be put into this slot.
+	   Usually slots have to be the same, but rings are exception:
+	   every ring may be worn on either hand. */
+	if itemSlot == slot {
+		return true
+	}
+	if (itemSlot == SlotRingLeft || itemSlot == SlotRingRight) &&
+		(slot == SlotRingLeft || slot == SlotRingRight) {
+		return true
+	}
+	return false
+}
+
+func (o *Object) UseItem(c *Creature, b Board, objs *Objects, cs Creatures) (bool, error) {
+	/* Method UseItem has Object as receiver and takes Creature as argument;
+	   game map, objects and creatures are passed to effects of item.
+	   Effects of item are executed by ApplyEffects method (check effects.go),
+	   and Creature c is both user and target of them.
+	   If item has no valid effects, ApplyEffects returns error,
+	   and turn is not spent.
+	   Items with charges can not be used if they are depleted, and every
+	   use drains one charge (check charges.go). Using unidentified item
+	   identifies it (check identification.go).
+	   It tries to remove item from inventory (or equipment) by calling
+	   DestroyItem function, but item will be removed only if its Consumable
+	   is set to true and - in case of charged items - all charges are used.
+	   Returns turnSpent that is true, unless item has no effects. */
 	turnSpent := false
-	var err error
-	switch o.Use {
-	case UseHeal:
-		c.HPCurrent = c.HPMax
-		turnSpent = true
-	default:
+	if len(o.Effects) == 0 {
 		txt := UseItemError()
-		err = errors.New("Item has wrong use case specified." + txt)
-		break
-	}
-	if err == nil {
-		AddMessage("You used " + o.Name + ".")
-		err2 := DestroyItem(o, c)
-		if err2 != nil {
-			fmt.Println(err2)
-			// It could be case to set turnSpent to false again.
-		}
+		err := errors.New("Item has no effects specified." + txt)
+		return turnSpent, err
+	}
+	if o.HasCharges() == false {
+		AddMessage("The " + DisplayName(o) + " has no charges left.")
+		return turnSpent, nil
+	}
+	turnSpent = true
+	c.SpendEnergy(ActionCostUse)
+	AddMessage("You used " + DisplayName(o) + ".")
+	if o.Identify() == true {
+		AddMessage("It was " + o.Name + ".")
+	}
+	err := o.ApplyEffects(c, c, c.X, c.Y, b, objs, cs)
+	if o.UseCharge() == false {
+		return turnSpent, err // Item still has charges, or is rechargeable.
+	}
+	err2 := DestroyItem(o, c)
+	if err2 != nil {
+		fmt.Println(err2)
+		// It could be case to set turnSpent to false again.
 	}
 	return turnSpent, err
 }
 
 func DestroyItem(o *Object, c *Creature) error {
 	/* Function DestroyItem takes Object and Creature as arguments, and returns error.
-	   At first, it iterates through Creature's Inventory, and creates an error if
-	   proper index is not found. Otherwise, it removes item from inventory. */
+	   At first, it iterates through Creature's Inventory, then through its
+	   Equipment, and creates an error if proper index is not found in any of them.
+	   Otherwise, it removes item from inventory, or empties equipment slot.
+	   If item is stack of more than one item, only its Quantity is decreased. */
 	var err error
 	if o.Consumable == true {
+		if o.Quantity > 1 {
+			o.Quantity--
+			return err
+		}
 		index, err_ := FindObjectIndex(o, c.Inventory)
+		if err_ == nil {
+			c.RemoveFromInventory(index)
+			return err
+		}
+		slot, err_ := FindObjectIndex(o, c.Equipment)
 		if err_ != nil {
 			err = err_ // It looks like ugly hack.
 			txt := ItemToDestroyNotFoundError()
 			fmt.Println(txt)
 		} else {
-			copy(c.Inventory[index:], c.Inventory[index+1:])
-			c.Inventory[len(c.Inventory)-1] = nil
-			c.Inventory = c.Inventory[:len(c.Inventory)-1]
+			c.Equipment[slot] = nil
+			c.ClampHP()
 		}
 	}
 	return err
@@ -405,6 +405,8 @@ func ReadOptionsControls() {
 	   trimmed of whitespaces and capitalized.
 	   Possible actions and values are listed in config file, as comments.
 	   If value of KB_LAYOUT is wrong, it falls back to QWERTY scheme.
+	   AUTO_PICKUP is list of item categories that player picks up
+	   automatically (check pickup.go).
 	   If controls scheme is set to custom (in case of problems it falls back
 	   to false) it uses private addKeyToCustomLayout function to
 	   create CustomCommandKeys (see controls.go). */
@@ -444,6 +446,8 @@ func ReadOptionsControls() {
 				fmt.Println("Wrong value in KB_LAYOUT; using QWERTY.")
 				KeyboardLayout = KB_QWERTY
 			}
+		} else if resKey == "AUTO_PICKUP" {
+			AutoPickup = ParseAutoPickup(results[1])
 		} else if resKey == "CUSTOM_CONTROLS" {
 			val := strings.TrimSpace(results[1])
 			if val == "TRUE" {
@@ -461,7 +465,8 @@ func ReadOptionsControls() {
 		resKey := strings.TrimSpace(results[0])
 		resValue := strings.TrimSpace(results[1])
 		if utf8.RuneCountInString(resKey) > 0 && []rune(resKey)[0] != '#' &&
-			resKey != "KB_LAYOUT" && resKey != "CUSTOM_CONTROLS" {
+			resKey != "KB_LAYOUT" && resKey != "CUSTOM_CONTROLS" &&
+			resKey != "AUTO_PICKUP" {
 			addKeyToCustomLayout(resKey, resValue)
 		}
 	}
@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"errors"
+)
+
+func ValidatePatrolPoint(point []int, size int) error {
+	/* Function ValidatePatrolPoint checks if point read from json map
+	   (waypoint, or guard post: [x, y]; or idle area: [x, y, w, h])
+	   has proper length, and lies within map. */
+	var err error
+	if len(point) != size {
+		txt := PatrolPointError(point)
+		err = errors.New("Patrol point has wrong number of values." + txt)
+		return err
+	}
+	x, y := point[0], point[1]
+	if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+		txt := CoordsError(x, y)
+		err = errors.New("Patrol point is out of map." + txt)
+	}
+	if size == 4 && (point[2] <= 0 || point[3] <= 0 ||
+		x+point[2] > MapSizeX || y+point[3] > MapSizeY) {
+		txt := PatrolPointError(point)
+		err = errors.New("Idle area is out of map." + txt)
+	}
+	return err
+}
+
+func (c *Creature) SetPatrol(waypoints [][]int, post, area []int) error {
+	/* Method SetPatrol assigns patrol route, guard post, and
+	   idle area (read from json map) to receiver. Empty post, or
+	   area, means "not set". Invalid data are ignored, and error
+	   is returned. */
+	var err error
+	for _, v := range waypoints {
+		if err = ValidatePatrolPoint(v, 2); err != nil {
+			return err
+		}
+	}
+	if len(post) > 0 {
+		if err = ValidatePatrolPoint(post, 2); err != nil {
+			return err
+		}
+	}
+	if len(area) > 0 {
+		if err = ValidatePatrolPoint(area, 4); err != nil {
+			return err
+		}
+	}
+	c.Waypoints = waypoints
+	c.Waypoint = 0
+	c.GuardPost = post
+	c.IdleArea = area
+	return err
+}
+
+func (c *Creature) InIdleArea(x, y int) bool {
+	/* Method InIdleArea returns true if x, y lies within
+	   idle area of receiver. */
+	a := c.IdleArea
+	return x >= a[0] && x < a[0]+a[2] && y >= a[1] && y < a[1]+a[3]
+}
+
+func (c *Creature) NextWaypoint(b Board, cs Creatures) (int, int) {
+	/* Method NextWaypoint returns coords of waypoint that receiver
+	   walks to. Once waypoint is reached, the next one is chosen;
+	   route is looped. Waypoint that is occupied by other Creature
+	   (or blocked) counts as reached when receiver stands next to it,
+	   so patrols do not get stuck. */
+	wp := c.Waypoints[c.Waypoint]
+	reached := c.X == wp[0] && c.Y == wp[1]
+	if reached == false && c.DistanceTo(wp[0], wp[1]) <= 1 {
+		m := GetAliveCreatureFromTile(wp[0], wp[1], cs)
+		reached = b[wp[0]][wp[1]].Blocked == true || (m != nil && m != c)
+	}
+	if reached == true {
+		c.Waypoint = (c.Waypoint + 1) % len(c.Waypoints)
+		wp = c.Waypoints[c.Waypoint]
+	}
+	return wp[0], wp[1]
+}
+
+func BehaviourPatrolHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourPatrolHandler is used by Creatures that
+	   are not fighting. Depending on data from json map, Creature:
+	   - walks its patrol route (Waypoints), waypoint by waypoint;
+	   - goes back to its GuardPost, and guards it;
+	   - wanders, but does not leave its IdleArea.
+	   Creatures without any of them fail this node (and usually
+	   wander aimlessly). As triggered Creatures give up searching
+	   after losing their target (see noise.go), they return
+	   to their routes and posts. Pather uses pathfinding. */
+	if c.Awareness == AwarenessAlert && AITarget(c, b, cs) != nil {
+		return false
+	}
+	switch {
+	case len(c.Waypoints) > 0:
+		x, y := c.NextWaypoint(b, cs)
+		c.MoveTowards(b, cs, x, y, MovementStyle(n))
+	case len(c.GuardPost) > 0:
+		if c.X != c.GuardPost[0] || c.Y != c.GuardPost[1] {
+			c.MoveTowards(b, cs, c.GuardPost[0], c.GuardPost[1], MovementStyle(n))
+		}
+	case len(c.IdleArea) > 0:
+		if c.InIdleArea(c.X, c.Y) == false {
+			x := c.IdleArea[0] + c.IdleArea[2]/2
+			y := c.IdleArea[1] + c.IdleArea[3]/2
+			c.MoveTowards(b, cs, x, y, MovementStyle(n))
+			break
+		}
+		dx, dy := RandRange(-1, 1), RandRange(-1, 1)
+		if c.InIdleArea(c.X+dx, c.Y+dy) == true &&
+			GetAliveCreatureFromTile(c.X+dx, c.Y+dy, cs) == nil {
+			c.Move(dx, dy, b)
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"strings"
+)
+
+const (
+	// Categories of items, used by auto-pickup rules
+	// (AUTO_PICKUP in options_controls.cfg).
+	PickupNone       = "NONE"
+	PickupAll        = "ALL"
+	PickupAmmo       = "AMMO"
+	PickupConsumable = "CONSUMABLE"
+	PickupEquippable = "EQUIPPABLE"
+	PickupStackable  = "STACKABLE"
+)
+
+func ParseAutoPickup(value string) []string {
+	/* Function ParseAutoPickup takes value of AUTO_PICKUP option,
+	   that is comma-separated list of categories, and returns
+	   it as slice of strings. NONE (or empty value) disables
+	   auto-pickup. Item kinds (like POTION, or SCROLL) are valid
+	   categories as well. */
+	var rules = []string{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v == "" || v == PickupNone {
+			continue
+		}
+		rules = append(rules, v)
+	}
+	return rules
+}
+
+func ItemCategories(o *Object) []string {
+	/* Function ItemCategories returns all auto-pickup categories
+	   that Object belongs to. Ammo are items that restore ammo
+	   of ranged weapons. */
+	var categories = []string{PickupAll}
+	for _, e := range o.Effects {
+		if e.Type == EffectRestoreAmmo {
+			categories = append(categories, PickupAmmo)
+			break
+		}
+	}
+	if o.Consumable == true {
+		categories = append(categories, PickupConsumable)
+	}
+	if o.Equippable == true {
+		categories = append(categories, PickupEquippable)
+	}
+	if o.Stackable == true {
+		categories = append(categories, PickupStackable)
+	}
+	if o.Kind != "" {
+		categories = append(categories, strings.ToUpper(o.Kind))
+	}
+	return categories
+}
+
+func MatchesAutoPickup(o *Object) bool {
+	/* Function MatchesAutoPickup returns true if Object belongs
+	   to at least one of categories listed in AutoPickup. */
+	for _, category := range ItemCategories(o) {
+		for _, rule := range AutoPickup {
+			if category == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ItemsOnTile(x, y int, o Objects) Objects {
+	/* Function ItemsOnTile returns all pickable Objects
+	   that lie on x, y tile. */
+	var items = Objects{}
+	for _, v := range o {
+		if v.X == x && v.Y == y && v.Pickable == true {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+func (c *Creature) PickUpItem(o *Objects, item *Object) bool {
+	/* Method PickUpItem moves item from "global" slice of objects
+	   to receiver's Inventory (stackable items are merged with
+	   stacks that are already there). It fails if there is
+	   no free slot in Inventory. */
+	if c.CanCarry(item) == false {
+		if c.AIType == PlayerAI {
+			AddMessage("Your inventory is full.")
+		}
+		return false
+	}
+	obj := *o
+	for i := 0; i < len(obj); i++ {
+		if obj[i] == item {
+			copy(obj[i:], obj[i+1:])
+			obj[len(obj)-1] = nil
+			*o = obj[:len(obj)-1]
+			break
+		}
+	}
+	if c.AIType == PlayerAI {
+		AddMessage("You found " + FormatObjectName(item) + ".")
+	}
+	c.AddToInventory(item)
+	return true
+}
+
+func (c *Creature) PickUpQuantity(o *Objects, item *Object, n int) bool {
+	/* Method PickUpQuantity picks up n items from stack that lies
+	   on the floor. Stack is split, and the rest stays on the floor;
+	   if n is not smaller than size of stack, the whole stack
+	   is picked up by PickUpItem. */
+	if n >= item.Quantity {
+		return c.PickUpItem(o, item)
+	}
+	if c.CanCarry(item) == false {
+		if c.AIType == PlayerAI {
+			AddMessage("Your inventory is full.")
+		}
+		return false
+	}
+	part := item.SplitStack(n)
+	if c.AIType == PlayerAI {
+		AddMessage("You found " + FormatObjectName(part) + ".")
+	}
+	c.AddToInventory(part)
+	return true
+}
+
+func (c *Creature) AutoPickUp(o *Objects) {
+	/* Method AutoPickUp is called after player's move. It picks up
+	   every item from player's tile that matches auto-pickup rules.
+	   Auto-pickup does not take additional time. */
+	if len(AutoPickup) == 0 {
+		return
+	}
+	for _, v := range ItemsOnTile(c.X, c.Y, *o) {
+		if MatchesAutoPickup(v) == true {
+			c.PickUpItem(o, v)
+		}
+	}
+}
+
+func (p *Creature) PickUpMenu(items Objects) Objects {
+	/* Method PickUpMenu lets player choose items to pick up from
+	   the pile. Letter keys select (or deselect) items, TAB selects
+	   (or deselects) all of them, and Enter confirms the choice.
+	   Returns selected items; Escape returns empty slice. */
+	selected := make([]bool, len(items))
+	for {
+		PrintPickUpMenu(UIPosX, UIPosY, "Pick up:", items, selected)
+		key := ReadInput()
+		switch key {
+		case blt.TK_ESCAPE:
+			return Objects{}
+		case blt.TK_ENTER, blt.TK_RETURN, blt.TK_KP_ENTER:
+			var chosen = Objects{}
+			for i, v := range items {
+				if selected[i] == true {
+					chosen = append(chosen, v)
+				}
+			}
+			return chosen
+		case blt.TK_TAB:
+			all := true
+			for _, v := range selected {
+				if v == false {
+					all = false
+				}
+			}
+			for i := range selected {
+				selected[i] = !all
+			}
+		default:
+			option := KeyToOrder(key)
+			if option >= 0 && option < len(items) {
+				selected[option] = !selected[option]
+			}
+		}
+	}
+}
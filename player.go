@@ -83,18 +83,27 @@ func NewPlayer(x, y int) (*Creature, error) {
 		txt := InitialDefenseError(player.Defense)
 		err2 = errors.New("Creature defense value is smaller than 0." + txt)
 	}
+	if errStatus := ValidateStatuses(player.HitStatuses); errStatus != nil {
+		err2 = errStatus
+	}
+	if player.Level < 1 {
+		player.Level = 1
+	}
+	if player.Speed <= 0 {
+		player.Speed = SpeedNormal
+	}
 	if player.Equipment == nil {
 		player.Equipment = Objects{}
 	}
-	if player.Inventory == nil {
-		player.Inventory = Objects{}
-	}
+	player.CompactInventory()
+	player.AdjustEquipmentSlots()
 	return player, err2
 }
 
-func (p *Creature) InventoryMenu(o *Objects) bool {
-	/* InventoryMenu is method of *Creature that takes *Objects as argument
-	   and returns boolean value - indicator if action took turn or not.
+func (p *Creature) InventoryMenu(b Board, o *Objects, cs Creatures) bool {
+	/* InventoryMenu is method of *Creature that takes game map, *Objects
+	   and Creatures as arguments (map and Creatures are necessary
+	   for throwing items) and returns boolean value - indicator if action took turn or not.
 	   It starts by loop that prints creature's (player) inventory and
 	   waits for input. Then changes input to alphabetic order.
 	   Handling input is simple - if input is within inventory range,
@@ -103,13 +112,15 @@ func (p *Creature) InventoryMenu(o *Objects) bool {
 	   otherwise, it loops. */
 	turnSpent := false
 	for {
-		PrintInventoryMenu(UIPosX, UIPosY, "Inventory", p.Inventory)
+		header := "Inventory (" + strconv.Itoa(len(p.Inventory)) + "/" +
+			strconv.Itoa(InventoryCapacity) + "), " + strconv.Itoa(p.Gold) + " gold"
+		PrintInventoryMenu(UIPosX, UIPosY, header, p.Inventory)
 		key := ReadInput()
 		option := KeyToOrder(key)
 		if option == KeyToOrder(blt.TK_ESCAPE) {
 			break
 		} else if option < len(p.Inventory) {
-			turnSpent = p.HandleInventory(o, option)
+			turnSpent = p.HandleInventory(b, o, cs, option)
 		} else {
 			continue
 		}
@@ -117,21 +128,23 @@ func (p *Creature) InventoryMenu(o *Objects) bool {
 	return turnSpent
 }
 
-func (p *Creature) HandleInventory(o *Objects, option int) bool {
+func (p *Creature) HandleInventory(b Board, o *Objects, cs Creatures,
+	option int) bool {
 	/* HandleInventory is method that has pointer to Creature as receiver,
 	   but it is supposed to be player every time. It takes
 	   slice of game objects and chosen option (that is index of item in Inventory)
 	   as arguments.
 	   It calls InventoryActions method for handling actions that are possible
 	   for specific item. */
-	turnSpent := p.InventoryActions(o, option)
+	turnSpent := p.InventoryActions(b, o, cs, option)
 	return turnSpent
 }
 
-func (p *Creature) InventoryActions(o *Objects, option int) bool {
+func (p *Creature) InventoryActions(b Board, o *Objects, cs Creatures,
+	option int) bool {
 	/* InventoryActions is method that has *Creature as receiver
-	   (that is supposed to be player) and takes *Objects and index
-	   of specific item (ie integer) as arguments.
+	   (that is supposed to be player) and takes game map, *Objects,
+	   Creatures and index of specific item (ie integer) as arguments.
 	   It loops rendering menu until proper input is provided.
 	   Loop is pretty complicated:
 	   - is labelled as Loop to make breaking simpler
@@ -152,7 +165,7 @@ Loop:
 		if err1 != nil {
 			fmt.Println(err1)
 		}
-		PrintMenu(UIPosX, UIPosY, object.Name, options)
+		PrintMenu(UIPosX, UIPosY, FormatObjectName(object), options)
 		var chosenStr string
 		chosenInt := KeyToOrder(ReadInput())
 		if chosenInt == KeyToOrder(blt.TK_ESCAPE) {
@@ -169,9 +182,12 @@ Loop:
 		case ItemDrop:
 			turnSpent = p.DropFromInventory(o, option)
 			break Loop
+		case ItemThrow:
+			turnSpent = p.ThrowFromInventory(b, o, cs, option)
+			break Loop
 		case ItemUse:
 			var err2 error
-			turnSpent, err2 = object.UseItem(p)
+			turnSpent, err2 = object.UseItem(p, b, o, cs)
 			if err2 != nil {
 				fmt.Println(err2)
 				turnSpent = false
@@ -191,7 +207,7 @@ func (p *Creature) EquipFromInventory(o *Object) bool {
 	   This method is used to equip item directly from inventory. */
 	turnSpent := false
 	for {
-		PrintEquipmentMenu(UIPosX, UIPosY, "Equipment:", p.Equipment)
+		PrintEquipmentMenu(UIPosX, UIPosY, "Equipment:", p)
 		key := ReadInput()
 		option := KeyToOrder(key)
 		if option == KeyToOrder(blt.TK_ESCAPE) {
@@ -200,7 +216,7 @@ func (p *Creature) EquipFromInventory(o *Object) bool {
 			if p.Equipment[option] != nil {
 				AddMessage("This slot is already occupied.")
 				continue
-			} else if option != o.Slot {
+			} else if SlotsMatch(o.Slot, option) == false {
 				AddMessage("You can't equip this here.")
 				continue
 			} else {
@@ -218,7 +234,7 @@ func (p *Creature) EquipFromInventory(o *Object) bool {
 	return turnSpent
 }
 
-func (p *Creature) EquipmentMenu(o *Objects) bool {
+func (p *Creature) EquipmentMenu(b Board, o *Objects, cs Creatures) bool {
 	/* EquipmentMenu start similar to InventoryMenu - it prints Equipment
 	   and waits for player input, then checks if input is valid.
 	   If test will pass, it tries to dequip item from selected slot;
@@ -226,14 +242,14 @@ func (p *Creature) EquipmentMenu(o *Objects) bool {
 	   provide list of all equippables items from Inventory. */
 	turnSpent := false
 	for {
-		PrintEquipmentMenu(UIPosX, UIPosY, "Equipment: ", p.Equipment)
+		PrintEquipmentMenu(UIPosX, UIPosY, "Equipment: ", p)
 		key := ReadInput()
 		option := KeyToOrder(key)
 		if option == KeyToOrder(blt.TK_ESCAPE) {
 			break
 		} else if option < SlotMax {
 			if p.Equipment[option] != nil {
-				turnSpent = p.EquipmentActions(o, option)
+				turnSpent = p.EquipmentActions(b, o, cs, option)
 			} else {
 				turnSpent = p.EquippablesMenu(option)
 			}
@@ -244,7 +260,8 @@ func (p *Creature) EquipmentMenu(o *Objects) bool {
 	return turnSpent
 }
 
-func (p *Creature) EquipmentActions(o *Objects, slot int) bool {
+func (p *Creature) EquipmentActions(b Board, o *Objects, cs Creatures,
+	slot int) bool {
 	/* Method EquipmentActions works as InventoryActions but for Equipment.
 	   Refer to InventoryActions for more detailed info, but remember that
 	   Inventory and Equipment, even if using the same architecture, may
@@ -257,7 +274,7 @@ Loop:
 		if err1 != nil {
 			fmt.Println(err1)
 		}
-		PrintMenu(UIPosX, UIPosY, object.Name, options)
+		PrintMenu(UIPosX, UIPosY, FormatObjectName(object), options)
 		var chosenStr string
 		chosenInt := KeyToOrder(ReadInput())
 		if chosenInt == KeyToOrder(blt.TK_ESCAPE) {
@@ -279,9 +296,16 @@ Loop:
 		case ItemDrop:
 			turnSpent = p.DropFromEquipment(o, slot)
 			break Loop
+		case ItemRepair:
+			var err4 error
+			turnSpent, err4 = p.RepairWithKit(object)
+			if err4 != nil {
+				fmt.Println(err4)
+			}
+			break Loop
 		case ItemUse:
 			var err3 error
-			turnSpent, err3 = object.UseItem(p)
+			turnSpent, err3 = object.UseItem(p, b, o, cs)
 			if err3 != nil {
 				fmt.Println(err3)
 				turnSpent = false
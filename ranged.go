@@ -144,30 +144,100 @@ func (c *Creature) Target(b Board, o *Objects, cs Creatures) bool {
 	   This method is "the big one", general, for handling targeting.
 	   In short, player starts targetting, line is drawn from player
 	   to monster, then function waits for input (confirmation - "fire",
-	   breaking the loop, or continuing).
+	   breaking the loop, or continuing). Choosing target is handled
+	   by AimCursor.
 	   Explicitly:
-	   - creates list of all potential targets in fov
-	    * tries to automatically last target, but
+	   - creates list of all potential targets in fov, and in range
+	     of weapon (see WeaponRange in ranged_ai.go)
+	   - lets player choose target using AimCursor
+	    * if player cancels, function ends
+	   - if player confirms, valley is shoot (in target, or empty space);
+	     weapons with area of effect explode (or affect cone) instead;
+	     weapons that use ammo can not be fired if they are empty
+	    * line between source and target is validated - monsterHit
+	      is the first monster in line of fire
+	    * if valley is shot in empty space, vector is extrapolated to check
+	      if it will hit any target */
+	turnSpent := false
+	weapon := c.RangedWeapon()
+	targetRange := WeaponRange(weapon)
+	targets := c.FindTargets(targetRange, b, cs, *o)
+	targetX, targetY, confirmed := c.AimCursor(b, *o, cs, targets)
+	if confirmed == false {
+		return turnSpent
+	}
+	if targetX == c.X && targetY == c.Y {
+		return turnSpent // Do not hurt yourself.
+	}
+	if c.DistanceTo(targetX, targetY) > targetRange {
+		AddMessage("Target is out of range.")
+		return turnSpent
+	}
+	if weapon != nil && weapon.AmmoMax > 0 {
+		if weapon.Ammo <= 0 {
+			AddMessage("Your " + DisplayName(weapon) + " is out of ammo.")
+			return turnSpent
+		}
+		weapon.Ammo--
+	}
+	if weapon != nil && weapon.BlastRadius > 0 {
+		c.FireAreaWeapon(weapon, targetX, targetY, b, o, cs)
+		turnSpent = true
+		return turnSpent
+	}
+	vec, err := NewBrensenham(c.X, c.Y, targetX, targetY)
+	if err != nil {
+		fmt.Println(err)
+	}
+	_ = ComputeBrensenham(vec)
+	valid, _, monsterHit, _ := ValidateBrensenham(vec, b, targets, *o)
+	monsterAimed := FindMonsterByXY(targetX, targetY, cs)
+	if monsterAimed != nil && monsterAimed != c && monsterAimed.HPCurrent > 0 && valid == true {
+		LastTarget = monsterAimed
+		c.AttackTarget(monsterAimed, b, o, cs)
+	} else {
+		if monsterHit != nil {
+			if monsterHit.HPCurrent > 0 {
+				LastTarget = monsterHit
+				c.AttackTarget(monsterHit, b, o, cs)
+			}
+		} else {
+			vx, vy := FindBrensenhamDirection(vec)
+			v := ExtrapolateBrensenham(vec, vx, vy)
+			_, _, monsterHitIndirectly, _ := ValidateBrensenham(v, b, targets, *o)
+			if monsterHitIndirectly != nil {
+				c.AttackTarget(monsterHitIndirectly, b, o, cs)
+			}
+		}
+	}
+	turnSpent = true
+	return turnSpent
+}
+
+func (c *Creature) AimCursor(b Board, o Objects, cs Creatures, targets Creatures) (int, int, bool) {
+	/* AimCursor is method of Creature (that is supposed to be player)
+	   that lets player choose target tile. It is used for firing,
+	   throwing, etc. "targets" is list of potential targets, sorted
+	   as explained in FindTargets docstring.
+	   Returns coords of chosen tile, and true if player confirmed
+	   target (by F or Enter), or false if player cancelled (by Escape).
+	   Explicitly:
+	   - it tries to automatically target last target, but
 	    * if fails, it targets the nearest enemy
 	   - draws line between source (receiver) and target (coords)
 	    * creates new vector
 	    * checks if it is valid - monsterHit should not be nil
 	    * prints brensenham's line (ie so-called "vector")
 	   - waits for player input
-	    * if player cancels, function ends
-	    * if player confirms, valley is shoot (in target, or empty space)
-	    * if valley is shot in empty space, vector is extrapolated to check
-	      if it will hit any target
-	    * player can switch between targets as well; it targets
+	    * player can switch between targets; it targets
 	      next target automatically; at first, only monsters that are
 	      valid target (ie clean shot is possible), then monsters that
 	      are in range and fov, but line of shot is not clear
 	    * in other cases, game will try to move cursor; invalid input
 	      is ignored */
-	turnSpent := false
 	var target *Creature
-	targets := c.FindTargets(FOVLength, b, cs, *o)
 	if LastTarget != nil && LastTarget != c &&
+		c.Relation(LastTarget) != RelationAllied &&
 		IsInFOV(b, c.X, c.Y, LastTarget.X, LastTarget.Y) == true {
 		target = LastTarget
 	} else {
@@ -185,41 +255,18 @@ func (c *Creature) Target(b Board, o *Objects, cs Creatures) bool {
 			fmt.Println(err)
 		}
 		_ = ComputeBrensenham(vec)
-		valid, _, monsterHit, _ := ValidateBrensenham(vec, b, targets, *o)
-		PrintBrensenham(vec, BrensenhamWhyTarget, BrensenhamColorGood, BrensenhamColorBad, b, *o, cs)
+		_, _, monsterHit, _ := ValidateBrensenham(vec, b, targets, o)
+		PrintBrensenham(vec, BrensenhamWhyTarget, BrensenhamColorGood, BrensenhamColorBad, b, o, cs)
 		if monsterHit != nil {
 			msg := "There is " + monsterHit.Name + " here."
 			PrintLookingMessage(msg, i)
 		}
 		key := ReadInput()
 		if key == blt.TK_ESCAPE {
-			break
+			return targetX, targetY, false
 		}
-		if key == blt.TK_F {
-			monsterAimed := FindMonsterByXY(targetX, targetY, cs)
-			if monsterAimed != nil && monsterAimed != c && monsterAimed.HPCurrent > 0 && valid == true {
-				LastTarget = monsterAimed
-				c.AttackTarget(monsterAimed, o)
-			} else {
-				if monsterAimed == c {
-					break // Do not hurt yourself.
-				}
-				if monsterHit != nil {
-					if monsterHit.HPCurrent > 0 {
-						LastTarget = monsterHit
-						c.AttackTarget(monsterHit, o)
-					}
-				} else {
-					vx, vy := FindBrensenhamDirection(vec)
-					v := ExtrapolateBrensenham(vec, vx, vy)
-					_, _, monsterHitIndirectly, _ := ValidateBrensenham(v, b, targets, *o)
-					if monsterHitIndirectly != nil {
-						c.AttackTarget(monsterHitIndirectly, o)
-					}
-				}
-			}
-			turnSpent = true
-			break
+		if key == blt.TK_F || key == blt.TK_ENTER {
+			return targetX, targetY, true
 		} else if key == blt.TK_TAB {
 			i = true
 			monster := FindMonsterByXY(targetX, targetY, cs)
@@ -234,7 +281,6 @@ func (c *Creature) Target(b Board, o *Objects, cs Creatures) bool {
 		CursorMovement(&targetX, &targetY, key)
 		i = true
 	}
-	return turnSpent
 }
 
 func CursorMovement(x, y *int, key int) {
@@ -281,14 +327,28 @@ func (c *Creature) FindTargets(length int, b Board, cs Creatures, o Objects) Cre
 	   It is necessary for autotarget feature - switching between targets
 	   player will start from the nearest valid target, to the farthest valid target;
 	   THEN, it will start to target "invalid" targets - again,
-	   from nearest to farthest one. */
-	targets := c.MonstersInFov(b, cs)
+	   from nearest to farthest one.
+	   Allies (like companions) are never listed, and hostile monsters
+	   go before neutral ones (like merchants), so player does not
+	   shoot friends by accident. */
+	var targets = Creatures{}
+	for _, v := range c.MonstersInFov(b, cs) {
+		if c.Relation(v) != RelationAllied {
+			targets = append(targets, v)
+		}
+	}
 	targetable, unreachable := c.MonstersInRange(b, targets, o, length)
 	sort.Slice(targetable, func(i, j int) bool {
+		if c.IsHostile(targetable[i]) != c.IsHostile(targetable[j]) {
+			return c.IsHostile(targetable[i])
+		}
 		return targetable[i].DistanceBetweenCreatures(c) <
 			targetable[j].DistanceBetweenCreatures(c)
 	})
 	sort.Slice(unreachable, func(i, j int) bool {
+		if c.IsHostile(unreachable[i]) != c.IsHostile(unreachable[j]) {
+			return c.IsHostile(unreachable[i])
+		}
 		return unreachable[i].DistanceBetweenCreatures(c) <
 			unreachable[j].DistanceBetweenCreatures(c)
 	})
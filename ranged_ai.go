@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+const (
+	// Range of ranged weapons that do not declare Range.
+	RangeDefault = FOVLength - 1
+	// The shortest engagement distance of ranged monsters.
+	EngageDistanceMin = 2
+	// Number of steps that ranged monster is willing to walk
+	// to find position with clear line of fire.
+	RepositionRadius = 4
+)
+
+func WeaponRange(weapon *Object) int {
+	/* Function WeaponRange returns maximum distance at which weapon
+	   may be fired. Weapons without Range use RangeDefault. */
+	if weapon == nil || weapon.Range <= 0 {
+		return RangeDefault
+	}
+	return weapon.Range
+}
+
+func (c *Creature) EngageDistance() int {
+	/* Method EngageDistance returns ideal distance between receiver
+	   and its target: half of range of its ranged weapon - close
+	   enough to not lose target, far enough to not be caught
+	   in melee. */
+	distance := WeaponRange(c.RangedWeapon()) / 2
+	if distance < EngageDistanceMin {
+		distance = EngageDistanceMin
+	}
+	return distance
+}
+
+func (c *Creature) InRange(t *Creature) bool {
+	/* Method InRange returns true if t may be shot by ranged
+	   weapon of receiver. Adjacent targets are never "in range",
+	   as they are attacked in melee. */
+	dist := c.DistanceTo(t.X, t.Y)
+	return dist > 1 && dist <= WeaponRange(c.RangedWeapon())
+}
+
+func BehaviourKiteHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourKiteHandler backs away from adjacent target,
+	   so receiver can use its ranged weapon. Node fails if receiver
+	   is cornered - then it has to fight in melee. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert || c.RangedWeapon() == nil ||
+		c.DistanceTo(t.X, t.Y) > 1 {
+		return false
+	}
+	return c.StepAway(b, cs, t.X, t.Y)
+}
+
+func BehaviourEngageHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourEngageHandler moves receiver towards target,
+	   until target is within ideal engagement distance. Creatures
+	   without ranged weapon approach target to melee it. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert {
+		return false
+	}
+	distance := 1
+	if c.RangedWeapon() != nil {
+		distance = c.EngageDistance()
+	}
+	if c.DistanceTo(t.X, t.Y) <= distance {
+		return false
+	}
+	c.MoveTowards(b, cs, t.X, t.Y, MovementStyle(n))
+	return true
+}
+
+func BehaviourRepositionHandler(n Behaviour, c *Creature, b Board,
+	cs Creatures, o *Objects) bool {
+	/* Function BehaviourRepositionHandler is used when line of fire
+	   is blocked. It finds the nearest reachable tile (within
+	   RepositionRadius) from which target is in range and may be
+	   shot directly, and moves towards it. */
+	t := AITarget(c, b, cs)
+	if t == nil || c.Awareness != AwarenessAlert || c.RangedWeapon() == nil {
+		return false
+	}
+	if c.InRange(t) == true && FirstOnLine(c.X, c.Y, t.X, t.Y, b, cs, *o) == t {
+		return false
+	}
+	x, y, ok := c.FindFiringTile(t, b, cs, *o)
+	if ok == false {
+		return false
+	}
+	before := c.Energy
+	c.MoveTowards(b, cs, x, y, MeleePatherAI)
+	return c.Energy != before
+}
+
+func (c *Creature) FindFiringTile(t *Creature, b Board, cs Creatures,
+	o Objects) (int, int, bool) {
+	/* Method FindFiringTile uses distance map (see morale.go) to find
+	   the nearest free tile, within RepositionRadius steps from receiver,
+	   from which target is in range, and line of fire is clear.
+	   Tiles adjacent to target are skipped. */
+	distances := DistanceMap(b, [][]int{[]int{c.X, c.Y}})
+	weaponRange := WeaponRange(c.RangedWeapon())
+	bestX, bestY, best := 0, 0, -1
+	for x := c.X - RepositionRadius; x <= c.X+RepositionRadius; x++ {
+		for y := c.Y - RepositionRadius; y <= c.Y+RepositionRadius; y++ {
+			if x < 0 || x >= MapSizeX || y < 0 || y >= MapSizeY {
+				continue
+			}
+			steps := distances[x][y].Weight
+			if steps <= 0 || steps > RepositionRadius {
+				continue
+			}
+			if best >= 0 && steps >= best {
+				continue
+			}
+			dist := DistanceBetween(x, y, t.X, t.Y)
+			if dist <= 1 || dist > weaponRange {
+				continue
+			}
+			if GetAliveCreatureFromTile(x, y, cs) != nil {
+				continue
+			}
+			if FirstOnLine(x, y, t.X, t.Y, b, cs, o) != t {
+				continue
+			}
+			bestX, bestY, best = x, y, steps
+		}
+	}
+	return bestX, bestY, best >= 0
+}
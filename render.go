@@ -139,12 +139,24 @@ func PrintUI(c *Creature) {
 	   It prints UI infos on the right side of screen.
 	   For now its functionality is very modest, but it will expand when
 	   new elements of game mechanics will be introduced. So, for now, it
-	   provides only one basic, yet essential information: player's HP. */
+	   provides only basic, yet essential informations: player's HP,
+	   attack and defense (including bonuses from equipment), level and
+	   experience bar, and active Statuses. */
 	blt.Layer(UILayer)
 	name := "Player"
 	blt.Print(UIPosX, UIPosY, name)
-	hp := "[color=red]HP: " + strconv.Itoa(c.HPCurrent) + "\\" + strconv.Itoa(c.HPMax)
+	hp := "[color=red]HP: " + strconv.Itoa(c.HPCurrent) + "\\" + strconv.Itoa(c.EffectiveHPMax())
 	blt.Print(UIPosX, UIPosY+1, hp)
+	att := "ATK: " + strconv.Itoa(c.EffectiveAttack())
+	blt.Print(UIPosX, UIPosY+2, att)
+	def := "DEF: " + strconv.Itoa(c.EffectiveDefense())
+	blt.Print(UIPosX, UIPosY+3, def)
+	lvl := "Level: " + strconv.Itoa(c.Level)
+	blt.Print(UIPosX, UIPosY+4, lvl)
+	blt.Print(UIPosX, UIPosY+5, FormatExperienceBar(c))
+	for i, v := range FormatStatuses(c) {
+		blt.Print(UIPosX, UIPosY+7+i, "[color=yellow]"+v)
+	}
 }
 
 func PrintLog() {
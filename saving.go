@@ -40,8 +40,23 @@ const (
 	CreaturesPathGob = "./" + CreaturesNameGob
 	ObjectsNameGob   = "objects.gob"
 	ObjectsPathGob   = "./" + ObjectsNameGob
+	GameNameGob      = "game.gob"
+	GamePathGob      = "./" + GameNameGob
 )
 
+type GameState struct {
+	/* GameState holds global data of current game, that is not
+	   part of map, monsters, nor objects: seed (and number of
+	   loot rolls made so far), depth, identification status
+	   of items, and relationships between factions. */
+	Seed        int64
+	LootDraws   int64
+	Depth       int
+	Appearances map[string]string
+	Identified  map[string]bool
+	Relations   map[string]map[string]string
+}
+
 const (
 	// Unique name that serves as identifier to values
 	// that should be converted from nil to object or from object to nil.
@@ -57,7 +72,19 @@ func NilToObject() *Object {
 		"black", "black"},
 		VisibilityProperties{0, false},
 		CollisionProperties{false, false},
-		ObjectProperties{false, false, false, 0, 0}}
+		ObjectProperties{false, false, false, 0, 0, nil, nil},
+		ModifierProperties{0, 0, 0},
+		AreaProperties{0, 0, 0},
+		ThrowingProperties{0, false},
+		StackProperties{false, 0},
+		AmmoProperties{0, 0},
+		RangeProperties{0},
+		ChargeProperties{0, 0, 0, 0},
+		ContainerProperties{false, nil, nil, ""},
+		IdentityProperties{""},
+		DurabilityProperties{0, 0},
+		PriceProperties{0, 0},
+		StealthProperties{0}}
 	return placeholder
 }
 
@@ -129,7 +156,9 @@ func loadCreatures(c *Creatures) error {
 	/* Function loadCreatures is helper function that decodes saved data
 	   to slice of creatures. Gob package has troubles with handling nil
 	   values, so every nil is represented as placeholder object.
-	   During decoding, every placeholder becomes nil again. */
+	   During decoding, every placeholder becomes nil again.
+	   Values that were not present in older saves are set to the
+	   same defaults as in NewCreature and NewPlayer. */
 	err := readGob(CreaturesPathGob, c)
 	for i := 0; i < len(*c); i++ {
 		objs := (*c)[i].Equipment
@@ -144,6 +173,28 @@ func loadCreatures(c *Creatures) error {
 				inv[k] = nil
 			}
 		}
+		(*c)[i].CompactInventory()
+		(*c)[i].AdjustEquipmentSlots()
+		for _, v := range (*c)[i].Equipment {
+			if v != nil {
+				v.MigrateUse()
+			}
+		}
+		for _, v := range (*c)[i].Inventory {
+			v.MigrateUse()
+		}
+		if (*c)[i].Speed <= 0 {
+			(*c)[i].Speed = SpeedNormal
+		}
+		if (*c)[i].Courage <= 0 {
+			(*c)[i].Courage = MoraleDefault
+		}
+		if (*c)[i].AIType == PlayerAI {
+			(*c)[i].Awareness = AwarenessAlert
+			if (*c)[i].Level < 1 {
+				(*c)[i].Level = 1
+			}
+		}
 	}
 	return err
 }
@@ -155,10 +206,54 @@ func saveObjects(o Objects) error {
 	return err
 }
 
+func saveGameState() error {
+	/* Function saveGameState is helper function that gathers global
+	   game data into GameState, and encodes it to save file. */
+	state := GameState{GameSeed, LootSource.Draws, CurrentDepth,
+		Appearances, Identified, Relations}
+	err := writeGob(GamePathGob, state)
+	return err
+}
+
+func loadGameState() error {
+	/* Function loadGameState is helper function that decodes saved
+	   GameState, and restores global game data.
+	   Generator of loot is restored to the state from save.
+	   Game state file was not present in older saves; in that
+	   case, game is loaded anyway, with new identification data
+	   and default relationships between factions. */
+	var state = GameState{}
+	var err error
+	if _, errStat := os.Stat(GamePathGob); errStat == nil {
+		err = readGob(GamePathGob, &state)
+	}
+	GameSeed = state.Seed
+	SeedLootRand(GameSeed, state.LootDraws)
+	CurrentDepth = state.Depth
+	Appearances = state.Appearances
+	Identified = state.Identified
+	if Appearances == nil {
+		Appearances = map[string]string{}
+	}
+	if Identified == nil {
+		Identified = map[string]bool{}
+	}
+	InitializeIdentification(false)
+	Relations = state.Relations
+	if Relations == nil {
+		InitializeFactions()
+	}
+	return err
+}
+
 func loadObjects(o *Objects) error {
 	/* Function loadObjects is helper function that decodes saved data
-	   to slice of objects. */
+	   to slice of objects. Use cases of items from older saves
+	   are converted into Effects. */
 	err := readGob(ObjectsPathGob, o)
+	for _, v := range *o {
+		v.MigrateUse()
+	}
 	return err
 }
 
@@ -180,6 +275,10 @@ func SaveGame(b Board, c Creatures, o Objects) error {
 	if err != nil {
 		fmt.Println(err)
 	}
+	err = saveGameState()
+	if err != nil {
+		fmt.Println(err)
+	}
 	return err
 }
 
@@ -201,6 +300,10 @@ func LoadGame(b *Board, c *Creatures, o *Objects) error {
 	if err != nil {
 		fmt.Println(err)
 	}
+	err = loadGameState()
+	if err != nil {
+		fmt.Println(err)
+	}
 	return err
 }
 
@@ -221,4 +324,8 @@ func DeleteSaves() {
 	if err == nil {
 		os.Remove(ObjectsPathGob)
 	}
+	_, err = os.Stat(GamePathGob)
+	if err == nil {
+		os.Remove(GamePathGob)
+	}
 }
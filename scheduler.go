@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import "sort"
+
+const (
+	/* Values for handling time. Every tick, Creature gains
+	   as much Energy as its Speed is; Creature may act when its
+	   Energy is equal or bigger than EnergyThreshold.
+	   Therefore, Creature with Speed 200 acts twice as often as
+	   Creature with SpeedNormal, and Creature with Speed 50 -
+	   twice less often. */
+	EnergyThreshold = 100
+	SpeedNormal     = 100
+	SpeedMin        = 1
+)
+
+const (
+	// Energy costs of actions.
+	ActionCostMove   = 100
+	ActionCostAttack = 100
+	ActionCostPickUp = 50
+	ActionCostDrop   = 50
+	ActionCostEquip  = 100
+	ActionCostUse    = 100
+	ActionCostThrow  = 100
+	ActionCostWait   = 100
+)
+
+func (c *Creature) SpendEnergy(cost int) {
+	/* Method SpendEnergy is called by every method that takes
+	   time - moving, attacking, using items, etc. */
+	c.Energy -= cost
+}
+
+func (c *Creature) EffectiveSpeed() int {
+	/* Method EffectiveSpeed returns Speed of receiver, modified
+	   by Statuses - slowed Creature is twice slower.
+	   Speed is never smaller than SpeedMin, so every Creature
+	   will act at some point. */
+	speed := c.Speed
+	if c.HasStatus(StatusSlow) == true {
+		speed = speed / 2
+	}
+	if speed < SpeedMin {
+		speed = SpeedMin
+	}
+	return speed
+}
+
+func Tick(b Board, c Creatures, o *Objects) {
+	/* Function Tick is single unit of game time (ie "turn").
+	   It recharges items and updates Statuses of every living Creature,
+	   then adds Energy to all of them that are able to act. */
+	for _, v := range c {
+		if v.AIType == NoAI || v.HPCurrent <= 0 {
+			continue
+		}
+		v.RechargeItems()
+		if v.UpdateStatuses(b, o, c) == true {
+			v.Energy += v.EffectiveSpeed()
+		}
+	}
+}
+
+func NextActor(c Creatures) *Creature {
+	/* Function NextActor returns Creature that will act as next one,
+	   or nil, if nobody has enough Energy.
+	   Order has to be deterministic, so replays stay consistent:
+	   Creature with the biggest Energy goes first; ties are resolved
+	   by order in Creatures slice (so player, that is the first
+	   element of slice, wins every tie). */
+	var ready = Creatures{}
+	for _, v := range c {
+		if v.AIType == NoAI || v.HPCurrent <= 0 {
+			continue
+		}
+		if v.Energy >= EnergyThreshold {
+			ready = append(ready, v)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].Energy > ready[j].Energy
+	})
+	return ready[0]
+}
@@ -36,6 +36,7 @@ const (
 	CreaturesPathJson = "./data/monsters/"
 	ObjectsPathJson   = "./data/objects/"
 	MapsPathJson      = "./data/maps/"
+	LootPathJson      = "./data/loot/"
 )
 
 func writeJson(path string, thing interface{}) error {
@@ -90,6 +91,13 @@ func ObjectFromJson(path string, o *Object) error {
 	return err
 }
 
+func LootTableFromJson(path string, l *LootTable) error {
+	/* Function LootTableFromJson decodes specific json file into
+	   LootTable, passed as argument. */
+	err := readJson(path, l)
+	return err
+}
+
 func MapFromJson(path string, m *MapJson) error {
 	/* Function MapFromJson decodes specific json file into MapJson,
 	   that is specific data type used to parse all json info (not only
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"strconv"
+)
+
+const (
+	// InventoryCapacity is number of inventory slots.
+	// Stack of items occupies one slot.
+	InventoryCapacity = 20
+)
+
+// DigitKeys maps number keys (and numpad keys) to digits;
+// it is used by quantity prompt.
+var DigitKeys = map[int]int{
+	blt.TK_0: 0, blt.TK_1: 1, blt.TK_2: 2, blt.TK_3: 3, blt.TK_4: 4,
+	blt.TK_5: 5, blt.TK_6: 6, blt.TK_7: 7, blt.TK_8: 8, blt.TK_9: 9,
+	blt.TK_KP_0: 0, blt.TK_KP_1: 1, blt.TK_KP_2: 2, blt.TK_KP_3: 3,
+	blt.TK_KP_4: 4, blt.TK_KP_5: 5, blt.TK_KP_6: 6, blt.TK_KP_7: 7,
+	blt.TK_KP_8: 8, blt.TK_KP_9: 9,
+}
+
+func (o *Object) StacksWith(other *Object) bool {
+	/* Method StacksWith returns true if receiver and other Object
+	   may be merged into one stack. Both have to be stackable,
+	   and have the same name. */
+	if o == other || o.Stackable == false || other.Stackable == false {
+		return false
+	}
+	return o.Name == other.Name
+}
+
+func (o *Object) SplitStack(n int) *Object {
+	/* Method SplitStack takes n items from receiver stack, and returns
+	   them as new Object. If n is not smaller than receiver's Quantity,
+	   receiver itself is returned. */
+	if n >= o.Quantity {
+		return o
+	}
+	split := *o
+	split.Quantity = n
+	o.Quantity -= n
+	return &split
+}
+
+func FormatObjectName(o *Object) string {
+	/* Function FormatObjectName returns name of Object, prefixed with
+	   its Quantity, if there is more than one item in stack -
+	   like "3x healing potion". Weapons that use ammo have number
+	   of remaining rounds appended - like "rifle (4/10)", and items
+	   with charges - number of charges, like "wand [2/3]".
+	   Unidentified items are shown with their appearance. */
+	name := DisplayName(o)
+	if o.Quantity > 1 {
+		name = strconv.Itoa(o.Quantity) + "x " + name
+	}
+	if o.AmmoMax > 0 {
+		name = name + " (" + strconv.Itoa(o.Ammo) + "/" +
+			strconv.Itoa(o.AmmoMax) + ")"
+	}
+	if o.ChargesMax > 0 {
+		name = name + " [" + strconv.Itoa(o.Charges) + "/" +
+			strconv.Itoa(o.ChargesMax) + "]"
+	}
+	return name
+}
+
+func (c *Creature) InventoryIsFull() bool {
+	/* Method InventoryIsFull returns true if every inventory slot
+	   of receiver is occupied. */
+	return len(c.Inventory) >= InventoryCapacity
+}
+
+func (c *Creature) CanCarry(o *Object) bool {
+	/* Method CanCarry returns true if Object can be put into
+	   receiver's Inventory - either merged with stack that is
+	   already there, or put into free slot. */
+	for _, v := range c.Inventory {
+		if v.StacksWith(o) {
+			return true
+		}
+	}
+	return c.InventoryIsFull() == false
+}
+
+func (c *Creature) CompactInventory() {
+	/* Method CompactInventory removes nil values from receiver's
+	   Inventory. Unlike Equipment, Inventory has no fixed slots,
+	   but json files (and old saves) may still contain nulls. */
+	var inv = Objects{}
+	for _, v := range c.Inventory {
+		if v != nil {
+			inv = append(inv, v)
+		}
+	}
+	c.Inventory = inv
+}
+
+func (c *Creature) AddToInventory(o *Object) bool {
+	/* Method AddToInventory puts Object into receiver's Inventory.
+	   Stackable items are merged with stack of the same kind, if
+	   present. Returns false if there is no place for new item. */
+	for _, v := range c.Inventory {
+		if v.StacksWith(o) {
+			v.Quantity += o.Quantity
+			return true
+		}
+	}
+	if c.InventoryIsFull() == true {
+		return false
+	}
+	c.Inventory = append(c.Inventory, o)
+	return true
+}
+
+func (c *Creature) RemoveFromInventory(index int) *Object {
+	/* Method RemoveFromInventory removes item at index from receiver's
+	   Inventory (whole stack), and returns it. */
+	o := c.Inventory[index]
+	copy(c.Inventory[index:], c.Inventory[index+1:])
+	c.Inventory[len(c.Inventory)-1] = nil
+	c.Inventory = c.Inventory[:len(c.Inventory)-1]
+	return o
+}
+
+func PlaceObject(item *Object, x, y int, o *Objects) {
+	/* Function PlaceObject puts item on map, at x, y. If there is
+	   stack of items of the same kind already, item is merged with it. */
+	for _, v := range *o {
+		if v.X == x && v.Y == y && v.StacksWith(item) {
+			v.Quantity += item.Quantity
+			return
+		}
+	}
+	item.X, item.Y = x, y
+	*o = append(*o, item)
+}
+
+func AskQuantity(header string, max int) int {
+	/* Function AskQuantity lets player type number of items
+	   (from 1 to max) to take from stack. Prompt starts with max,
+	   so Enter alone takes the whole stack. Digits are typed,
+	   Backspace removes the last one, and Escape cancels prompt -
+	   then, 0 is returned. */
+	value := strconv.Itoa(max)
+	typed := false
+	for {
+		PrintQuantityPrompt(UIPosX, UIPosY, header, max, value)
+		key := ReadInput()
+		switch key {
+		case blt.TK_ESCAPE:
+			return 0
+		case blt.TK_ENTER, blt.TK_RETURN, blt.TK_KP_ENTER:
+			n, err := strconv.Atoi(value)
+			if err == nil && n >= 1 && n <= max {
+				return n
+			}
+		case blt.TK_BACKSPACE:
+			if len(value) > 0 {
+				value = value[:len(value)-1]
+			}
+			typed = true
+		default:
+			digit, ok := DigitKeys[key]
+			if ok == false {
+				continue
+			}
+			if typed == false {
+				value = ""
+				typed = true
+			}
+			value = value + strconv.Itoa(digit)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"errors"
+	"strconv"
+)
+
+const (
+	// Types of status effects.
+	StatusNA = iota
+
+	StatusPoison
+	StatusBurn
+	StatusStun
+	StatusSlow
+	StatusStrength
+	StatusProtection
+
+	StatusMax
+)
+
+const (
+	/* Stacking rules, ie what happens if Creature is affected
+	   by status that is already active:
+	   - StackRefresh keeps longer duration and stronger power,
+	   - StackDuration adds durations,
+	   - StackIntensity adds powers and keeps longer duration. */
+	StackRefresh = iota
+	StackDuration
+	StackIntensity
+)
+
+var StatusStrings = map[int]string{
+	StatusPoison:     "poisoned",
+	StatusBurn:       "burning",
+	StatusStun:       "stunned",
+	StatusSlow:       "slowed",
+	StatusStrength:   "strengthened",
+	StatusProtection: "protected",
+}
+
+// StatusKillers are names used in message log, when Creature
+// dies from damage dealt by Status.
+var StatusKillers = map[int]string{
+	StatusPoison: "the poison",
+	StatusBurn:   "the fire",
+}
+
+var StatusStacking = map[int]int{
+	StatusPoison:     StackIntensity,
+	StatusBurn:       StackRefresh,
+	StatusStun:       StackRefresh,
+	StatusSlow:       StackDuration,
+	StatusStrength:   StackRefresh,
+	StatusProtection: StackRefresh,
+}
+
+type Status struct {
+	/* Status is single effect that affects Creature for
+	   Duration turns (ie Ticks, see scheduler.go). Meaning of Power depends on Type - it is
+	   damage per turn for poison and burn, bonus to attack
+	   for strength, etc. Stun and slow ignore Power.
+	   FromPlayer is set if Status was applied by player, so
+	   player is credited for kills made by poison or burn. */
+	Type       int
+	Duration   int
+	Power      int
+	FromPlayer bool
+}
+
+// Statuses holds all effects - active ones, or ready to apply.
+type Statuses []Status
+
+func ValidateStatuses(s Statuses) error {
+	/* Function ValidateStatuses checks if every Status in slice
+	   has proper type and positive duration. It is used during
+	   creating Creatures and Objects from json files. */
+	var err error
+	for _, v := range s {
+		if v.Type <= StatusNA || v.Type >= StatusMax || v.Duration <= 0 {
+			txt := StatusError(v.Type, v.Duration)
+			err = errors.New("Status has wrong type or duration." + txt)
+		}
+	}
+	return err
+}
+
+func (c *Creature) AddStatus(s Status, source *Creature) {
+	/* Method AddStatus applies Status to receiver. If Creature is
+	   already affected by the same type of Status, new one is merged
+	   with old one, regarding to stacking rules declared in StatusStacking.
+	   Otherwise, Status is appended to the list of active effects.
+	   Source is Creature that applied Status; it may be nil. */
+	s.FromPlayer = source != nil && source.AIType == PlayerAI
+	for i, v := range c.Statuses {
+		if v.Type != s.Type {
+			continue
+		}
+		if s.FromPlayer == true {
+			c.Statuses[i].FromPlayer = true
+		}
+		switch StatusStacking[s.Type] {
+		case StackDuration:
+			c.Statuses[i].Duration += s.Duration
+		case StackIntensity:
+			c.Statuses[i].Power += s.Power
+			if s.Duration > v.Duration {
+				c.Statuses[i].Duration = s.Duration
+			}
+		default:
+			if s.Duration > v.Duration {
+				c.Statuses[i].Duration = s.Duration
+			}
+			if s.Power > v.Power {
+				c.Statuses[i].Power = s.Power
+			}
+		}
+		return
+	}
+	c.Statuses = append(c.Statuses, s)
+	if c.AIType == PlayerAI {
+		AddMessage("You are " + StatusStrings[s.Type] + "!")
+	}
+}
+
+func (c *Creature) AddStatuses(s Statuses, source *Creature) {
+	/* Method AddStatuses calls AddStatus for every Status in slice. */
+	for _, v := range s {
+		c.AddStatus(v, source)
+	}
+}
+
+func (c *Creature) StatusPower(statusType int) int {
+	/* Method StatusPower returns Power of active Status of
+	   specified type, or 0 if receiver is not affected by it. */
+	for _, v := range c.Statuses {
+		if v.Type == statusType {
+			return v.Power
+		}
+	}
+	return 0
+}
+
+func (c *Creature) HasStatus(statusType int) bool {
+	/* Method HasStatus returns true if receiver is affected
+	   by Status of specified type. */
+	for _, v := range c.Statuses {
+		if v.Type == statusType {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Creature) UpdateStatuses(b Board, o *Objects, cs Creatures) bool {
+	/* Method UpdateStatuses is called once per turn, for every Creature.
+	   It applies per-turn effects (like damage from poison), then
+	   decreases durations and removes expired Statuses.
+	   If damage kills receiver, CombatEvent is emitted, so death
+	   is handled the same way as death in combat.
+	   It returns false if receiver can not act in this turn - because
+	   it is stunned, or it died due to damage. Slow is handled by
+	   EffectiveSpeed, in scheduler.go. */
+	canAct := true
+	var active = Statuses{}
+	for _, v := range c.Statuses {
+		if c.HPCurrent <= 0 {
+			break
+		}
+		switch v.Type {
+		case StatusPoison, StatusBurn:
+			name := c.Name
+			c.TakeDamage(v.Power, o)
+			if c.HPCurrent <= 0 {
+				c.EmitStatusKill(v, name, b, o, cs)
+			}
+		case StatusStun:
+			canAct = false
+		}
+		v.Duration--
+		if v.Duration > 0 {
+			active = append(active, v)
+		} else if c.AIType == PlayerAI {
+			AddMessage("You are no longer " + StatusStrings[v.Type] + ".")
+		}
+	}
+	if c.HPCurrent <= 0 {
+		return false
+	}
+	c.Statuses = active
+	return canAct
+}
+
+func (c *Creature) EmitStatusKill(s Status, name string, b Board,
+	o *Objects, cs Creatures) {
+	/* Method EmitStatusKill emits CombatEvent about receiver killed
+	   by Status. Name is name of receiver before death.
+	   Player is the attacker if Status was applied by player;
+	   otherwise, receiver is attacker of itself. */
+	attacker := c
+	if s.FromPlayer == true {
+		attacker = cs[0]
+	}
+	event := CombatEvent{Attacker: attacker, Target: c,
+		AttackerName: StatusKillers[s.Type], TargetName: name,
+		Roll: s.Power, Damage: s.Power, Kill: true, Status: true}
+	EmitCombatEvent(event, b, o, cs)
+}
+
+func FormatStatuses(c *Creature) []string {
+	/* Function FormatStatuses returns list of active Statuses of
+	   Creature, formatted to be printed in UI, for example:
+	   "poisoned (3)". */
+	var txt = []string{}
+	for _, v := range c.Statuses {
+		txt = append(txt, StatusStrings[v.Type]+" ("+strconv.Itoa(v.Duration)+")")
+	}
+	return txt
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+const (
+	// Awareness states of Creatures. Unaware is zero value, so
+	// Creatures that do not declare Awareness are awake, but
+	// do not expect enemies.
+	AwarenessUnaware = iota
+	AwarenessAsleep
+	AwarenessSuspicious
+	AwarenessAlert
+)
+
+const (
+	// Chance (in %) to notice enemy in sight, depending on awareness
+	// of observer; it is lowered by NoticeDistance for every tile
+	// between them, and by stealth of enemy - but never below
+	// NoticeMin. Sleeping Creatures do not see anything.
+	NoticeUnaware    = 60
+	NoticeSuspicious = 90
+	NoticeDistance   = 5
+	NoticeMin        = 5
+	// Stealth bonuses of sneaking, and of hiding in darkness.
+	StealthSneak = 30
+	StealthDark  = 30
+	// Chance (in %) to wake up, per level of heard noise.
+	NoiseWakeChance = 10
+	// Sneaking makes moves slower, and quieter.
+	ActionCostSneak = 150
+	NoiseSneak      = 1
+)
+
+func (c *Creature) Stealth(b Board) int {
+	/* Method Stealth returns stealth score of receiver: sum of
+	   Stealth of its equipment (heavy armor is noisy, and has
+	   negative Stealth), with bonuses for sneaking, and for
+	   standing on dark tile. */
+	stealth := 0
+	for _, v := range c.Equipment {
+		if v != nil {
+			stealth += v.Stealth
+		}
+	}
+	if c.Sneaking == true {
+		stealth += StealthSneak
+	}
+	if b[c.X][c.Y].Dark == true {
+		stealth += StealthDark
+	}
+	return stealth
+}
+
+func (c *Creature) NoticeChance(t *Creature, b Board) int {
+	/* Method NoticeChance returns chance (in %) that receiver
+	   notices t during its turn. */
+	chance := 0
+	switch c.Awareness {
+	case AwarenessAsleep:
+		return chance
+	case AwarenessUnaware:
+		chance = NoticeUnaware
+	default:
+		chance = NoticeSuspicious
+	}
+	chance = chance - t.Stealth(b) - NoticeDistance*c.DistanceTo(t.X, t.Y)
+	if chance < NoticeMin {
+		chance = NoticeMin
+	}
+	return chance
+}
+
+func (c *Creature) Notice(t *Creature, cs Creatures) {
+	/* Method Notice raises awareness of receiver that noticed t.
+	   Unaware Creature becomes suspicious, and goes to check what
+	   it saw; suspicious one becomes alert, and alerts its group. */
+	switch c.Awareness {
+	case AwarenessAsleep, AwarenessUnaware:
+		c.Awareness = AwarenessSuspicious
+		c.Remember(t.X, t.Y)
+	case AwarenessSuspicious:
+		c.Alert(t, cs)
+	}
+}
+
+func (c *Creature) Alert(t *Creature, cs Creatures) {
+	/* Method Alert makes receiver alert, and aware of position
+	   of t. Its whole group is alerted as well. */
+	if c.Awareness != AwarenessAlert {
+		c.AlertGroup(t, cs)
+	}
+	c.Awareness = AwarenessAlert
+	c.Remember(t.X, t.Y)
+}
+
+func (c *Creature) WakeUp(level int) bool {
+	/* Method WakeUp is called when sleeping receiver hears noise.
+	   The louder noise, the bigger chance to wake up. Returns true
+	   if receiver is awake. */
+	if c.Awareness != AwarenessAsleep {
+		return true
+	}
+	if RandInt(100) >= level*NoiseWakeChance {
+		return false
+	}
+	c.Awareness = AwarenessUnaware
+	return true
+}
+
+func (c *Creature) ToggleSneak() bool {
+	/* Method ToggleSneak switches movement mode of player between
+	   walking and sneaking. Sneaking is slower, but quieter, and makes
+	   player harder to notice. It does not take turn. */
+	c.Sneaking = !c.Sneaking
+	if c.Sneaking == true {
+		AddMessage("You start sneaking.")
+	} else {
+		AddMessage("You stop sneaking.")
+	}
+	return false
+}
+
+func (c *Creature) SneakAttackBonus(t *Creature) int {
+	/* Method SneakAttackBonus returns bonus damage of melee attack
+	   of receiver against t that did not notice it: sleeping targets
+	   take additional damage equal to attack of receiver, unaware
+	   ones - half of it. Ranged attacks, and attacks against
+	   suspicious or alert targets, have no bonus. Player and
+	   companions are never caught off guard. */
+	if c.DistanceTo(t.X, t.Y) > 1 || t.AIType == PlayerAI || t.AIType == CompanionAI {
+		return 0
+	}
+	switch t.Awareness {
+	case AwarenessAsleep:
+		return c.EffectiveAttack()
+	case AwarenessUnaware:
+		return c.EffectiveAttack() / 2
+	}
+	return 0
+}
+
+func Disturb(e CombatEvent, b Board, o *Objects, cs Creatures) {
+	/* Function Disturb is listener of combat event stream. Creature
+	   that was attacked, and survived, becomes alert, and knows
+	   where its attacker is. */
+	if e.Attacker == nil || e.Target == nil || e.Attacker == e.Target {
+		return
+	}
+	if e.Target.HPCurrent <= 0 || e.Target.AIType == PlayerAI {
+		return
+	}
+	e.Target.Alert(e.Attacker, cs)
+}
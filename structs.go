@@ -61,13 +61,117 @@ type FighterProperties struct {
 	   it may be used for destructible environment
 	   elements as well.
 	   AI types are iota (integers) defined
-	   in creatures.go. */
-	AIType      int
-	AITriggered bool
-	HPMax       int
-	HPCurrent   int
-	Attack      int
-	Defense     int
+	   in creatures.go.
+	   HitStatuses are effects applied to target after
+	   successful attack (like venomous bite).
+	   Speed and Energy are used by scheduler - check
+	   scheduler.go for details.
+	   Creatures with DeathBlastRadius bigger than 0
+	   explode after death.
+	   Behaviours are building blocks of AI (check behaviours.go);
+	   if empty, defaults for AIType are used.
+	   Faction decides who is enemy, and who is ally - check
+	   factions.go. Members of the same Group share awareness,
+	   and cooperate - check groups.go. */
+	AIType           int
+	Behaviours       Behaviours
+	Faction          string
+	Group            string
+	HPMax            int
+	HPCurrent        int
+	Attack           int
+	Defense          int
+	HitStatuses      Statuses
+	Speed            int
+	Energy           int
+	DeathBlastRadius int
+	DeathBlastDamage int
+}
+
+type ExperienceProperties struct {
+	/* ExperienceProperties stores information about
+	   Creature's progress. Level and XP are used
+	   by player; XPValue is amount of experience that
+	   player receives for killing this Creature. */
+	Level   int
+	XP      int
+	XPValue int
+}
+
+type LootProperties struct {
+	/* LootTable is name of json file (from data/loot) with loot
+	   table that is rolled during creating Creature. Rolled items
+	   are put into Creature's Inventory, and may be looted from
+	   its corpse. Check loot.go for details. */
+	LootTable string
+}
+
+type TradeProperties struct {
+	/* Gold is currency used for trading with merchants.
+	   Stock is list of json files (from data/objects) with
+	   items that merchant sells; they are created during
+	   creating Creature, and put into its Inventory.
+	   Check trade.go for details. */
+	Gold  int
+	Stock []string
+}
+
+type MoraleProperties struct {
+	/* Creature flees when its fear (that depends on lost HP, and
+	   on number of enemies) is bigger than its Courage. Fearless
+	   Creatures never flee. Fleeing is current state of Creature.
+	   Check morale.go for details. */
+	Courage  int
+	Fearless bool
+	Fleeing  bool
+}
+
+type AwarenessProperties struct {
+	/* Awareness is state of Creature: asleep, unaware, suspicious,
+	   or alert - only alert Creatures fight their enemies.
+	   Sneaking is movement mode: Creature that sneaks is slower,
+	   but quieter, and harder to notice. Check stealth.go
+	   for details. */
+	Awareness int
+	Sneaking  bool
+}
+
+type StealthProperties struct {
+	/* Stealth of equipped items is added to stealth of Creature;
+	   heavy, noisy armor has negative Stealth. */
+	Stealth int
+}
+
+type MemoryProperties struct {
+	/* LastKnownX, LastKnownY is position that Creature wants to
+	   investigate - the last known position of its target, or
+	   origin of noise it heard. SearchTurns is number of turns
+	   left before Creature gives up. Check noise.go for details. */
+	LastKnownX    int
+	LastKnownY    int
+	Investigating bool
+	SearchTurns   int
+}
+
+type CompanionProperties struct {
+	/* Order is current order given by player to companion: follow
+	   (default), stay, or attack. OrderTarget is index (in slice of
+	   all Creatures) of Creature that companion was ordered to attack.
+	   Check companions.go for details. */
+	Order       string
+	OrderTarget int
+}
+
+type PatrolProperties struct {
+	/* Waypoints is looped patrol route, and Waypoint is index of
+	   waypoint that Creature walks to. GuardPost is [x, y] of tile
+	   that Creature guards; IdleArea is [x, y, width, height] of area
+	   that Creature wanders in. All of them are optional, and are
+	   set by json map. Check patrol.go for details. */
+	Waypoints [][]int
+	Waypoint  int
+	GuardPost []int
+	IdleArea  []int
 }
 
 type ObjectProperties struct {
@@ -75,20 +179,147 @@ type ObjectProperties struct {
 	   also, not every Object can be equipped - like cheese.
 	   It's place for other properties - like slot it will
 	   occupy, use cases, etc.
-	   Note that currently Equippable can not be Consumable,
-	   due to removing from Inventory / Equipment problems. */
-	Pickable   bool
-	Equippable bool
-	Consumable bool
-	Slot       int
-	Use        int
+	   Items that are both Equippable and Consumable (like wands)
+	   are destroyed after using their last charge.
+	   HitStatuses are applied to target hit by this Object (if
+	   it is weapon), and Effects are executed when Creature
+	   uses this Object (check effects.go).
+	   Use is use case of items from older saves; it is converted
+	   into Effects during loading (check MigrateUse). */
+	Pickable    bool
+	Equippable  bool
+	Consumable  bool
+	Slot        int
+	Use         int
+	HitStatuses Statuses
+	Effects     Effects
+}
+
+type ModifierProperties struct {
+	/* ModifierProperties holds bonuses (or penalties, if values
+	   are negative) that Object grants to Creature that has
+	   this Object equipped. They are not applied to Creature
+	   base stats directly - check Effective* methods
+	   in monsters.go. */
+	AttackModifier  int
+	DefenseModifier int
+	HPMaxModifier   int
+}
+
+type StatusComponent struct {
+	/* StatusComponent is part of Creature. It holds
+	   list of all active Statuses, like poison or stun.
+	   Statuses are declared in statuses.go. */
+	Statuses Statuses
+}
+
+type AreaProperties struct {
+	/* AreaProperties are used by weapons that hit more than
+	   one target - like grenades or flamethrowers.
+	   Weapon with BlastRadius bigger than 0 fires projectile that
+	   explodes; if ConeSpread (in degrees) is bigger than 0,
+	   weapon affects cone instead, and BlastRadius is its length.
+	   Check explosions.go for details. */
+	BlastRadius int
+	BlastDamage int
+	ConeSpread  int
+}
+
+type ThrowingProperties struct {
+	/* Every item that can be picked up can be thrown as well.
+	   ThrowDamage is damage dealt to Creature hit by thrown item.
+	   Items that Shatter are destroyed on impact - instead of
+	   landing on the map, they apply their Effects to
+	   Creature hit, and explode if BlastRadius is bigger than 0
+	   (think about potions, or hand grenades).
+	   Check throwing.go for details. */
+	ThrowDamage int
+	Shatters    bool
+}
+
+type StackProperties struct {
+	/* Stackable items (like potions, or grenades) of the same kind
+	   occupy one inventory slot; Quantity is size of stack.
+	   Quantity of non-stackable items is always 1.
+	   Check stacks.go for details. */
+	Stackable bool
+	Quantity  int
+}
+
+type AmmoProperties struct {
+	/* Ranged weapons with AmmoMax bigger than 0 use ammo;
+	   every shot uses one round, and weapon can not be fired
+	   if Ammo drops to 0. Other weapons have unlimited ammo. */
+	Ammo    int
+	AmmoMax int
+}
+
+type RangeProperties struct {
+	/* Range is maximum distance at which ranged weapon may be
+	   fired. Weapons with Range 0 use default range (see
+	   ranged_ai.go). */
+	Range int
+}
+
+type ChargeProperties struct {
+	/* Items with ChargesMax bigger than 0 (like wands, or gadgets)
+	   lose one charge every time they are used, and can not be used
+	   if there are no charges left. Consumable items are destroyed
+	   after using last charge. Items with RechargeTime bigger than 0
+	   restore one charge every RechargeTime turns; RechargeCounter
+	   counts turns until next charge. Check charges.go for details. */
+	Charges         int
+	ChargesMax      int
+	RechargeTime    int
+	RechargeCounter int
+}
+
+type ContainerProperties struct {
+	/* Containers (like chests, wardrobes, or corpses) are Objects
+	   that hold other Objects in their Contents. ContentsFiles is
+	   list of json files of items that are put into container
+	   during its creation; LootTable (json file from data/loot)
+	   is rolled and added to Contents as well.
+	   Containers can not be picked up.
+	   Check containers.go for details. */
+	Container     bool
+	Contents      Objects
+	ContentsFiles []string
+	LootTable     string
+}
+
+type IdentityProperties struct {
+	/* Items with Kind (like "potion", or "scroll") are unidentified
+	   until player uses them, or reads scroll of identify. Until then,
+	   they are shown with random appearance of their Kind.
+	   Check identification.go for details. */
+	Kind string
+}
+
+type PriceProperties struct {
+	/* Price is amount of gold that player pays merchant for
+	   this Object; SellPrice is amount of gold that merchant
+	   pays player. If SellPrice is 0, it is computed from Price.
+	   Objects with Price 0 can not be traded. */
+	Price     int
+	SellPrice int
+}
+
+type DurabilityProperties struct {
+	/* Equippable items with DurabilityMax bigger than 0 wear out:
+	   weapons when used in attack, armor when its wearer is hit.
+	   Items with Durability 0 are broken - they give only half of
+	   their bonuses, until repaired. Check durability.go for details. */
+	Durability    int
+	DurabilityMax int
 }
 
 type EquipmentComponent struct {
 	/* EquipmentComponent helps with inventory management.
 	   It's part of Creature.
 	   Equipment is list of equipped items. It uses
-	   const declared in objects.go.
+	   const declared in objects.go, and its length
+	   is supposed to be equal to SlotMax.
 	   Inventory is list of items in backpack. */
 	Equipment Objects
 	Inventory Objects
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// ThrowRange is maximal distance that item may be thrown at.
+	ThrowRange = FOVLength
+)
+
+func (c *Creature) ThrowFromInventory(b Board, o *Objects, cs Creatures,
+	index int) bool {
+	/* Method ThrowFromInventory is called when player chooses "throw"
+	   action in inventory menu. It reuses targeting cursor
+	   (see AimCursor in ranged.go) to choose target tile, then
+	   calls ThrowItem. Returns true if item was thrown. */
+	targets := c.FindTargets(ThrowRange, b, cs, *o)
+	tx, ty, confirmed := c.AimCursor(b, *o, cs, targets)
+	if confirmed == false || (tx == c.X && ty == c.Y) {
+		return false
+	}
+	return c.ThrowItem(index, tx, ty, b, o, cs)
+}
+
+func (c *Creature) ThrowItem(index, tx, ty int, b Board, o *Objects,
+	cs Creatures) bool {
+	/* Method ThrowItem throws item from receiver's Inventory (at index)
+	   towards tx, ty.
+	   Target is shortened to ThrowRange, if necessary. Item flies along
+	   Brensenham's line until it hits blocked tile or living Creature
+	   (check DeliverProjectile in explosions.go).
+	   Creature that is hit takes ThrowDamage; then:
+	   - items that Shatter are destroyed; their Effects are executed
+	     at impact point (and affect Creature hit, if any), and they
+	     explode if BlastRadius > 0;
+	   - other items land on the map, at impact point.
+	   Only one item from stack is thrown.
+	   Returns true, as throwing always takes time. */
+	item := c.Inventory[index]
+	if item.Quantity > 1 {
+		item = item.SplitStack(1) // Throw only one item from stack.
+	} else {
+		c.RemoveFromInventory(index)
+	}
+	c.SpendEnergy(ActionCostThrow)
+	if c.AIType == PlayerAI {
+		AddMessage("You throw " + DisplayName(item) + ".")
+	}
+	tx, ty = ClampThrowTarget(c.X, c.Y, tx, ty)
+	x, y := DeliverProjectile(c.X, c.Y, tx, ty, b, cs)
+	t := GetAliveCreatureFromTile(x, y, cs)
+	if t != nil && t != c && item.ThrowDamage > 0 {
+		event := CombatEvent{Attacker: c, Target: t, AttackerName: c.Name,
+			TargetName: t.Name, Roll: item.ThrowDamage,
+			Damage: item.ThrowDamage}
+		t.TakeDamage(item.ThrowDamage, o)
+		event.Kill = t.HPCurrent <= 0
+		EmitCombatEvent(event, b, o, cs)
+		if t.HPCurrent > 0 {
+			t.AddStatuses(item.HitStatuses, c)
+		}
+	}
+	if item.Shatters == false {
+		PlaceObject(item, x, y, o)
+		return true
+	}
+	if c.AIType == PlayerAI && IsInFOV(b, c.X, c.Y, x, y) == true {
+		AddMessage("The " + DisplayName(item) + " shatters!")
+	}
+	if len(item.Effects) > 0 {
+		err := item.ApplyEffects(c, t, x, y, b, o, cs)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	if item.BlastRadius > 0 {
+		c.Explode(x, y, item.BlastRadius, item.BlastDamage, b, o, cs)
+	}
+	return true
+}
+
+func ClampThrowTarget(sx, sy, tx, ty int) (int, int) {
+	/* Function ClampThrowTarget moves tx, ty closer to sx, sy,
+	   so distance between these points is not bigger than ThrowRange.
+	   Direction of throw is kept. */
+	dx, dy := float64(tx-sx), float64(ty-sy)
+	dist := math.Sqrt(dx*dx + dy*dy)
+	if dist <= ThrowRange {
+		return tx, ty
+	}
+	ratio := ThrowRange / dist
+	return sx + RoundFloatToInt(dx*ratio), sy + RoundFloatToInt(dy*ratio)
+}
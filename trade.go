@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2018, Tomasz "VedVid" Nowakowski
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+   list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package main
+
+import (
+	blt "bearlibterminal"
+	"strconv"
+)
+
+const (
+	// Percent of Price that merchants pay for items
+	// without explicit SellPrice.
+	SellRatio = 50
+)
+
+const (
+	// Panes of trade menu.
+	PaneMerchant = iota
+	PanePlayer
+)
+
+const (
+	// Trade menu covers the whole window; both panes are
+	// of the same width.
+	TradePaneSizeX = WindowSizeX / 2
+)
+
+func (c *Creature) FillStock() error {
+	/* Method FillStock creates Objects listed (as json file names)
+	   in Stock of receiver, and puts them into its Inventory.
+	   Merchants sell items from their Inventory. */
+	var err error
+	for _, v := range c.Stock {
+		item, err2 := NewObject(c.X, c.Y, v)
+		if err2 != nil {
+			err = err2
+		}
+		c.AddToInventory(item)
+	}
+	return err
+}
+
+func BuyPrice(o *Object) int {
+	/* Function BuyPrice returns price of single item that player
+	   has to pay to merchant. */
+	return o.Price
+}
+
+func SellPrice(o *Object) int {
+	/* Function SellPrice returns amount of gold that merchant
+	   pays for single item. If SellPrice of Object is not set,
+	   it is computed from Price, using SellRatio. */
+	if o.SellPrice > 0 {
+		return o.SellPrice
+	}
+	return o.Price * SellRatio / 100
+}
+
+func (p *Creature) Trade(m *Creature) bool {
+	/* Method Trade is called when player bumps into merchant.
+	   It shows merchant's Inventory and player's Inventory side
+	   by side, in two panes; TAB key moves focus between them.
+	   Choosing item from merchant's pane buys it, choosing item
+	   from player's pane sells it. Only one item from stack is
+	   traded at once. Returns true if anything was bought or sold. */
+	turnSpent := false
+	pane := PaneMerchant
+	for {
+		blt.Clear()
+		merchantHeader := "  Buy: " + m.Name + " (" + strconv.Itoa(m.Gold) + "g)"
+		playerHeader := "  Sell: you (" + strconv.Itoa(p.Gold) + "g)"
+		if pane == PaneMerchant {
+			merchantHeader = ">" + merchantHeader[1:]
+		} else {
+			playerHeader = ">" + playerHeader[1:]
+		}
+		PrintTradeMenu(0, 0, TradePaneSizeX, merchantHeader, m.Inventory,
+			BuyPrice, pane == PaneMerchant)
+		PrintTradeMenu(TradePaneSizeX, 0, TradePaneSizeX, playerHeader, p.Inventory,
+			SellPrice, pane == PanePlayer)
+		blt.Print(0, WindowSizeY-1, "[[TAB]] switch pane, [[ESC]] back")
+		blt.Refresh()
+		key := ReadInput()
+		if key == blt.TK_ESCAPE {
+			break
+		} else if key == blt.TK_TAB {
+			if pane == PaneMerchant {
+				pane = PanePlayer
+			} else {
+				pane = PaneMerchant
+			}
+			continue
+		}
+		option := KeyToOrder(key)
+		if pane == PaneMerchant && option >= 0 && option < len(m.Inventory) {
+			if p.BuyItem(m, option) == true {
+				turnSpent = true
+			}
+		} else if pane == PanePlayer && option >= 0 && option < len(p.Inventory) {
+			if p.SellItem(m, option) == true {
+				turnSpent = true
+			}
+		}
+	}
+	return turnSpent
+}
+
+func (p *Creature) BuyItem(m *Creature, index int) bool {
+	/* Method BuyItem moves one item from merchant's Inventory to
+	   receiver's Inventory, and moves gold in opposite direction.
+	   It fails if item has no price, if receiver can not afford it,
+	   or if there is no place in receiver's Inventory. */
+	item := m.Inventory[index]
+	price := BuyPrice(item)
+	if price <= 0 {
+		AddMessage(m.Name + " does not sell " + DisplayName(item) + ".")
+		return false
+	}
+	if p.Gold < price {
+		AddMessage("You can not afford " + DisplayName(item) + ".")
+		return false
+	}
+	if p.CanCarry(item) == false {
+		AddMessage("Your inventory is full.")
+		return false
+	}
+	bought := item
+	if item.Quantity > 1 {
+		bought = item.SplitStack(1)
+	} else {
+		m.RemoveFromInventory(index)
+	}
+	p.AddToInventory(bought)
+	p.Gold -= price
+	m.Gold += price
+	AddMessage("You bought " + DisplayName(bought) + " for " +
+		strconv.Itoa(price) + " gold.")
+	p.SpendEnergy(ActionCostPickUp)
+	return true
+}
+
+func (p *Creature) SellItem(m *Creature, index int) bool {
+	/* Method SellItem moves one item from receiver's Inventory to
+	   merchant's Inventory, and moves gold in opposite direction.
+	   Merchants do not buy worthless items, and can not pay more
+	   gold than they have, nor carry more than InventoryCapacity. */
+	item := p.Inventory[index]
+	price := SellPrice(item)
+	if price <= 0 {
+		AddMessage(m.Name + " is not interested in " + DisplayName(item) + ".")
+		return false
+	}
+	if m.Gold < price {
+		AddMessage(m.Name + " can not afford " + DisplayName(item) + ".")
+		return false
+	}
+	if m.CanCarry(item) == false {
+		AddMessage(m.Name + " has no place for " + DisplayName(item) + ".")
+		return false
+	}
+	sold := item
+	if item.Quantity > 1 {
+		sold = item.SplitStack(1)
+	} else {
+		p.RemoveFromInventory(index)
+	}
+	m.AddToInventory(sold)
+	p.Gold += price
+	m.Gold -= price
+	AddMessage("You sold " + DisplayName(sold) + " for " +
+		strconv.Itoa(price) + " gold.")
+	p.SpendEnergy(ActionCostDrop)
+	return true
+}
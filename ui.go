@@ -30,6 +30,7 @@ import (
 	blt "bearlibterminal"
 	"errors"
 	"fmt"
+	"strconv"
 	"unicode/utf8"
 )
 
@@ -75,12 +76,70 @@ func PrintInventoryMenu(x, y int, header string, options Objects) {
 	   I'd like to just pass Objects to the PrintMenu func. */
 	var opts = []string{}
 	for _, v := range options {
-		opts = append(opts, v.Name)
+		opts = append(opts, FormatObjectName(v))
 	}
 	PrintMenu(x, y, header, opts)
 }
 
-func PrintEquipmentMenu(x, y int, header string, options Objects) {
+func PrintTradeMenu(x, y, width int, header string, options Objects,
+	price func(*Object) int, focused bool) {
+	/* PrintTradeMenu prints one pane of trade menu: header, and list
+	   of items, every one followed by its price - computed by price
+	   function passed as argument, because buying and selling prices
+	   differ. Lines are cut to width, so two panes may be printed
+	   side by side. Only focused pane reacts to letter keys, so
+	   letters are shown only there.
+	   Unlike PrintMenu, it neither clears nor refreshes terminal -
+	   it is done by Trade, once for both panes. */
+	txt := header
+	for i, v := range options {
+		line := FormatObjectName(v) + " - " + strconv.Itoa(price(v)) + "g"
+		if focused == true {
+			line = OrderToCharacter(i) + ") " + line
+		} else {
+			line = "   " + line
+		}
+		txt = txt + "\n" + CutString(line, width-1)
+	}
+	blt.Print(x, y, txt)
+}
+
+func CutString(s string, width int) string {
+	/* Function CutString shortens s to width characters. */
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
+
+func PrintQuantityPrompt(x, y int, header string, max int, value string) {
+	/* PrintQuantityPrompt prints prompt used by AskQuantity:
+	   header, range of valid values, and value typed so far. */
+	blt.ClearArea(UIPosX, UIPosY, UISizeX, UISizeY)
+	txt := header + " (1-" + strconv.Itoa(max) + ")\n> " + value +
+		"\n[[ENTER]] confirm\n[[ESC]] back"
+	blt.Print(x, y, txt)
+	blt.Refresh()
+}
+
+func PrintPickUpMenu(x, y int, header string, options Objects,
+	selected []bool) {
+	/* PrintPickUpMenu works as PrintInventoryMenu, but selected
+	   items are marked with "+" sign, and header explains keys
+	   used for multiple choice. */
+	header = header + "\n[[TAB]] all, [[ENTER]] confirm"
+	var opts = []string{}
+	for i, v := range options {
+		mark := "- "
+		if selected[i] == true {
+			mark = "+ "
+		}
+		opts = append(opts, mark+FormatObjectName(v))
+	}
+	PrintMenu(x, y, header, opts)
+}
+
+func PrintEquipmentMenu(x, y int, header string, c *Creature) {
 	/* Similar to PrintInventoryMenu, but it sorts options
 	   by their Slots initially, and slot in showed before
 	   item name.
@@ -88,13 +147,17 @@ func PrintEquipmentMenu(x, y int, header string, options Objects) {
 	   not all equippable objects in inventory.
 	   Because of this, it is necessary to find "true" length
 	   of options, skipping all nil pointers.
-	   Unfortunately, it may crash in future, with
-	   more slots involved. */
+	   Header is followed by Creature's stats, computed from
+	   base values and everything equipped. Durability of items
+	   is shown after their names. */
+	options := c.Equipment
+	header = header + "\n" + FormatStats(c)
 	var opts = []string{}
 	for i := 0; i < len(options); i++ {
 		txt := ""
 		if options[i] != nil {
-			txt = "[[" + SlotStrings[i] + "]] " + options[i].Name
+			txt = "[[" + SlotStrings[i] + "]] " + FormatObjectName(options[i]) +
+				FormatDurability(options[i])
 		} else {
 			txt = "[[" + SlotStrings[i] + "]] empty"
 		}
@@ -103,11 +166,20 @@ func PrintEquipmentMenu(x, y int, header string, options Objects) {
 	PrintMenu(x, y, header, opts)
 }
 
+func FormatStats(c *Creature) string {
+	/* Function FormatStats returns short summary of Creature's
+	   effective stats, short enough to fit in UI. */
+	txt := "ATK " + strconv.Itoa(c.EffectiveAttack()) +
+		" DEF " + strconv.Itoa(c.EffectiveDefense()) +
+		" HP " + strconv.Itoa(c.EffectiveHPMax())
+	return txt
+}
+
 func PrintEquippables(x, y int, header string, options Objects) {
 	/* PrintEquippables is function that prints list of equippables. */
 	var opts = []string{}
 	for _, v := range options {
-		opts = append(opts, v.Name)
+		opts = append(opts, FormatObjectName(v))
 	}
 	PrintMenu(x, y, header, opts)
 }